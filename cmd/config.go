@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/claudioscheer/frame-arrangement/pkg/frames"
+	"gopkg.in/yaml.v3"
+)
+
+// WallConfig names a wall definition so a single config file can carry
+// several (e.g. "living-room", "hallway") and main can batch-generate one
+// arrangement per wall without recompiling.
+type WallConfig struct {
+	Name string      `json:"name"`
+	Wall frames.Wall `json:"wall"`
+}
+
+// Config composes the library's placement config with the driver-only
+// concerns of running from the command line: where to write the result
+// and which renderer to use.
+type Config struct {
+	frames.Config
+	// Walls, if non-empty, overrides Config.Wall with a named list of wall
+	// definitions: main renders one arrangement per entry, each to its own
+	// output path. Leave empty to use the single Config.Wall as before.
+	Walls        []WallConfig `json:"walls"`
+	OutputPath   string       `json:"outputPath"`
+	RenderFormat string       `json:"renderFormat"`
+}
+
+// DefaultConfig returns the configuration that main used to have baked in,
+// so running without a -config flag behaves exactly as before.
+func DefaultConfig() Config {
+	return Config{
+		Config:       frames.DefaultConfig(),
+		OutputPath:   "wall_visualization.png",
+		RenderFormat: "png",
+	}
+}
+
+// walls returns the wall definitions main should render, one arrangement
+// per entry. When Walls wasn't set, it falls back to a single unnamed
+// entry built from Config.Wall, so callers can always range over this
+// without special-casing the common single-wall case.
+func (c Config) walls() []WallConfig {
+	if len(c.Walls) > 0 {
+		return c.Walls
+	}
+	return []WallConfig{{Wall: c.Wall}}
+}
+
+// LoadConfig reads a JSON or YAML config file and merges it over
+// DefaultConfig, so a partial file (e.g. just a different wall) still
+// yields sane defaults for everything it omits. The format is chosen by
+// path's extension (".yaml"/".yml" for YAML, anything else as JSON).
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("reading config %q: %w", path, err)
+	}
+
+	if err := unmarshalConfig(path, data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("parsing config %q: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// unmarshalConfig decodes data into cfg, dispatching on path's extension.
+// YAML is decoded into a generic value first and re-marshaled to JSON so
+// it's parsed against the same `json` struct tags as the JSON path,
+// rather than requiring every field to carry a second `yaml` tag.
+func unmarshalConfig(path string, data []byte, cfg *Config) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var generic interface{}
+		if err := yaml.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		jsonData, err := json.Marshal(generic)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(jsonData, cfg)
+	default:
+		return json.Unmarshal(data, cfg)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/claudioscheer/frame-arrangement/pkg/frames"
+)
+
+func TestConfigWallsFallsBackToSingleWall(t *testing.T) {
+	cfg := DefaultConfig()
+	walls := cfg.walls()
+	if len(walls) != 1 {
+		t.Fatalf("got %d walls, want 1", len(walls))
+	}
+	if walls[0].Wall != cfg.Wall {
+		t.Errorf("fallback wall = %+v, want Config.Wall %+v", walls[0].Wall, cfg.Wall)
+	}
+}
+
+func TestConfigWallsUsesNamedList(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Walls = []WallConfig{
+		{Name: "living-room", Wall: frames.Wall{Width: 200, Height: 100}},
+		{Name: "hallway", Wall: frames.Wall{Width: 80, Height: 240}},
+	}
+
+	walls := cfg.walls()
+	if len(walls) != 2 {
+		t.Fatalf("got %d walls, want 2", len(walls))
+	}
+	if walls[0].Name != "living-room" || walls[1].Name != "hallway" {
+		t.Errorf("walls = %+v, want names in config order", walls)
+	}
+}
+
+func TestOutputPathForNamesBatchOutputs(t *testing.T) {
+	cases := []struct {
+		base string
+		wall WallConfig
+		want string
+	}{
+		{"wall.png", WallConfig{}, "wall.png"},
+		{"wall.png", WallConfig{Name: "hallway"}, "wall-hallway.png"},
+		{"out/wall.svg", WallConfig{Name: "den"}, "out/wall-den.svg"},
+	}
+	for _, c := range cases {
+		if got := outputPathFor(c.base, c.wall); got != c.want {
+			t.Errorf("outputPathFor(%q, %+v) = %q, want %q", c.base, c.wall, got, c.want)
+		}
+	}
+}
+
+func TestLoadConfigJSONAndYAMLAgree(t *testing.T) {
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "config.json")
+	jsonBody := `{"wall":{"width":120,"height":80},"coverageTarget":0.5,"packer":"maxrects"}`
+	if err := os.WriteFile(jsonPath, []byte(jsonBody), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	yamlPath := filepath.Join(dir, "config.yaml")
+	yamlBody := "wall:\n  width: 120\n  height: 80\ncoverageTarget: 0.5\npacker: maxrects\n"
+	if err := os.WriteFile(yamlPath, []byte(yamlBody), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fromJSON, err := LoadConfig(jsonPath)
+	if err != nil {
+		t.Fatalf("LoadConfig(json): %v", err)
+	}
+	fromYAML, err := LoadConfig(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadConfig(yaml): %v", err)
+	}
+
+	if fromJSON.Wall != fromYAML.Wall {
+		t.Errorf("Wall = %+v (json) vs %+v (yaml)", fromJSON.Wall, fromYAML.Wall)
+	}
+	if fromJSON.CoverageTarget != fromYAML.CoverageTarget {
+		t.Errorf("CoverageTarget = %v (json) vs %v (yaml)", fromJSON.CoverageTarget, fromYAML.CoverageTarget)
+	}
+	if fromJSON.Packer != fromYAML.Packer {
+		t.Errorf("Packer = %q (json) vs %q (yaml)", fromJSON.Packer, fromYAML.Packer)
+	}
+	if fromYAML.Packer != "maxrects" {
+		t.Errorf("Packer = %q, want maxrects", fromYAML.Packer)
+	}
+}
+
+func TestLoadConfigRejectsBadYAML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("wall: [this is not a mapping"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadConfig(path); err == nil {
+		t.Fatal("expected an error for malformed YAML, got nil")
+	}
+}
@@ -1,171 +1,122 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
-	"image"
 	"image/color"
-	"image/draw"
-	"image/png"
-	"math/rand"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
+
+	"github.com/claudioscheer/frame-arrangement/pkg/frames"
+	"github.com/claudioscheer/frame-arrangement/render"
 )
 
-// Wall dimensions
-type Wall struct {
-	width  int
-	height int
+// toRenderFrame converts a placed frames.Frame into the render package's
+// geometry+style representation, falling back to a deterministic
+// per-index content color when a frame has no explicit style (so
+// unstyled catalogs still render as distinguishable rectangles, matching
+// the look of the original hard-coded visualization).
+func toRenderFrame(i int, f frames.Frame) render.Frame {
+	style := f.Style
+	if style.ContentColor.A == 0 {
+		style.ContentColor = defaultContentColor(i)
+	}
+	return render.Frame{
+		X: f.X, Y: f.Y,
+		Width: f.Width, Height: f.Height,
+		Style: style,
+	}
 }
 
-// Frame dimensions
-type Frame struct {
-	width  int
-	height int
-	x      int // x position
-	y      int // y position
+// defaultContentColor reproduces the original per-index placeholder fill
+// so arrangements built from an unstyled frame catalog still render as
+// distinguishable rectangles.
+func defaultContentColor(i int) color.RGBA {
+	return color.RGBA{R: uint8(100 + i*20), G: uint8(50 + i*15), B: uint8(150 + i*10), A: 255}
 }
 
-// Checks if the frame overlaps with any existing frames
-func hasCollision(newFrame Frame, frames []Frame, margin int) bool {
-	for _, frame := range frames {
-		if newFrame.x < frame.x+frame.width+margin &&
-			newFrame.x+newFrame.width+margin > frame.x &&
-			newFrame.y < frame.y+frame.height+margin &&
-			newFrame.y+newFrame.height+margin > frame.y {
-			return true
-		}
+// outputPathFor derives the per-wall output path for a batch of walls: a
+// single (unnamed) wall keeps the configured path unchanged, and a named
+// wall gets its name inserted before the extension so a batch run doesn't
+// overwrite one output with the next.
+func outputPathFor(base string, wall WallConfig) string {
+	if wall.Name == "" {
+		return base
 	}
-	return false
+	ext := filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "-" + wall.Name + ext
 }
 
-// Tries to place frames randomly near previous frames until it fills 85% of the wall
-func placeFrames(wall Wall, frameSizes []Frame, marginRange [2]int, rng *rand.Rand) []Frame {
-	var placedFrames []Frame
-	requiredArea := int(float64(wall.width*wall.height) * 0.54)
-	totalArea := 0
-
-	// Place the first frame randomly on the wall
-	firstFrame := frameSizes[0]
-	firstFrame.x, firstFrame.y = rng.Intn(wall.width-firstFrame.width), rng.Intn(wall.height-firstFrame.height)
-	placedFrames = append(placedFrames, firstFrame)
-	totalArea += firstFrame.width * firstFrame.height
-
-	// Place subsequent frames near previous frames with some random offset
-	for totalArea < requiredArea {
-		fmt.Println("Total Area in Percentage: ", (totalArea*100)/(wall.width*wall.height))
-		rng.Shuffle(len(frameSizes), func(i, j int) {
-			frameSizes[i], frameSizes[j] = frameSizes[j], frameSizes[i]
-		})
-
-		for _, frame := range frameSizes {
-			if totalArea >= requiredArea {
-				break
-			}
-
-			margin := rng.Intn(marginRange[1]-marginRange[0]) + marginRange[0]
-			placed := false
-
-			// Try to place the frame near each existing frame with more candidate positions
-			for _, prevFrame := range placedFrames {
-				// Generate 12 potential positions around the existing frame
-				candidates := []Frame{
-					{width: frame.width, height: frame.height, x: prevFrame.x - frame.width - margin, y: prevFrame.y},                                 // Left
-					{width: frame.width, height: frame.height, x: prevFrame.x + prevFrame.width + margin, y: prevFrame.y},                             // Right
-					{width: frame.width, height: frame.height, x: prevFrame.x, y: prevFrame.y - frame.height - margin},                                // Above
-					{width: frame.width, height: frame.height, x: prevFrame.x, y: prevFrame.y + prevFrame.height + margin},                            // Below
-					{width: frame.width, height: frame.height, x: prevFrame.x - frame.width - margin, y: prevFrame.y - margin},                        // Top-left
-					{width: frame.width, height: frame.height, x: prevFrame.x + prevFrame.width + margin, y: prevFrame.y - margin},                    // Top-right
-					{width: frame.width, height: frame.height, x: prevFrame.x - frame.width - margin, y: prevFrame.y + prevFrame.height + margin},     // Bottom-left
-					{width: frame.width, height: frame.height, x: prevFrame.x + prevFrame.width + margin, y: prevFrame.y + prevFrame.height + margin}, // Bottom-right
-					{width: frame.width, height: frame.height, x: prevFrame.x - frame.width, y: prevFrame.y - frame.height},                           // Top-left diagonal
-					{width: frame.width, height: frame.height, x: prevFrame.x + prevFrame.width, y: prevFrame.y - frame.height},                       // Top-right diagonal
-					{width: frame.width, height: frame.height, x: prevFrame.x - frame.width, y: prevFrame.y + prevFrame.height},                       // Bottom-left diagonal
-					{width: frame.width, height: frame.height, x: prevFrame.x + prevFrame.width, y: prevFrame.y + prevFrame.height},                   // Bottom-right diagonal
-				}
-
-				// Shuffle the candidates and try placing them
-				rng.Shuffle(len(candidates), func(i, j int) {
-					candidates[i], candidates[j] = candidates[j], candidates[i]
-				})
-
-				for _, candidate := range candidates {
-					// Check bounds and collisions
-					if candidate.x >= 0 && candidate.y >= 0 &&
-						candidate.x+candidate.width <= wall.width &&
-						candidate.y+candidate.height <= wall.height &&
-						!hasCollision(candidate, placedFrames, margin) {
-						placedFrames = append(placedFrames, candidate)
-						totalArea += candidate.width * candidate.height
-						placed = true
-						break
-					}
-				}
-
-				if placed {
-					break
-				}
-			}
-
-			// If no valid position was found, try another frame
-			if !placed {
-				continue
-			}
-		}
+// NewRenderer resolves a renderer by its config name.
+func NewRenderer(format string) (render.Renderer, error) {
+	switch format {
+	case "", "png":
+		return render.PNGRenderer{}, nil
+	case "svg":
+		return render.SVGRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown render format %q", format)
 	}
-
-	return placedFrames
 }
 
-// Draw the frames on the wall image and save as a PNG
-func visualize(wall Wall, frames []Frame) {
-	// Create a white background
-	img := image.NewRGBA(image.Rect(0, 0, wall.width, wall.height))
-	white := color.RGBA{255, 255, 255, 255}
-	draw.Draw(img, img.Bounds(), &image.Uniform{white}, image.Point{}, draw.Src)
-
-	// Draw each frame as a colored rectangle
-	for i, frame := range frames {
-		frameColor := color.RGBA{uint8(100 + i*20), uint8(50 + i*15), uint8(150 + i*10), 255}
-		for x := frame.x; x < frame.x+frame.width; x++ {
-			for y := frame.y; y < frame.y+frame.height; y++ {
-				img.Set(x, y, frameColor)
-			}
+func main() {
+	configPath := flag.String("config", "", "path to a JSON or YAML config file (wall, frames, margins, coverage, seed, output)")
+	outputPath := flag.String("output", "", "override the output path")
+	seed := flag.Int64("seed", 0, "override the RNG seed (0 = keep the config's seed, which is time-based when unset)")
+	timeout := flag.Duration("timeout", 30*time.Second, "max time to spend placing frames before giving up (the random-neighbor packer has no termination guarantee of its own)")
+	flag.Parse()
+
+	cfg := DefaultConfig()
+	if *configPath != "" {
+		loaded, err := LoadConfig(*configPath)
+		if err != nil {
+			fmt.Println("Error loading config:", err)
+			os.Exit(1)
 		}
+		cfg = loaded
 	}
-
-	// Save the image to a file
-	file, err := os.Create("wall_visualization.png")
-	if err != nil {
-		fmt.Println("Error creating file:", err)
-		return
+	if *outputPath != "" {
+		cfg.OutputPath = *outputPath
 	}
-	defer file.Close()
-
-	if err := png.Encode(file, img); err != nil {
-		fmt.Println("Error encoding PNG:", err)
-		return
+	if *seed != 0 {
+		cfg.Seed = *seed
 	}
 
-	fmt.Println("Visualization saved as wall_visualization.png")
-}
+	renderer, err := NewRenderer(cfg.RenderFormat)
+	if err != nil {
+		fmt.Println("Error selecting renderer:", err)
+		os.Exit(1)
+	}
 
-func main() {
-	// Create a new random number generator with a time-based seed
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for _, wallCfg := range cfg.walls() {
+		frameCfg := cfg.Config
+		frameCfg.Wall = wallCfg.Wall
 
-	wall := Wall{width: 230, height: 140}
-	frameSizes := []Frame{
-		{width: 10, height: 15},
-		{width: 15, height: 10},
-		{width: 13, height: 18},
-		{width: 18, height: 13},
-		{width: 16, height: 9},
-		{width: 9, height: 9},
-	}
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		arrangement, err := frames.Arrange(ctx, frameCfg)
+		cancel()
+		if err != nil {
+			fmt.Println("Error arranging frames:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Packed %d frames (%d unplaced), %.1f%% coverage\n",
+			len(arrangement.Frames), arrangement.Unplaced, arrangement.Efficiency*100)
 
-	marginRange := [2]int{2, 5} // Reduced margin range for tighter packing
+		renderFrames := make([]render.Frame, len(arrangement.Frames))
+		for i, f := range arrangement.Frames {
+			renderFrames[i] = toRenderFrame(i, f)
+		}
 
-	placedFrames := placeFrames(wall, frameSizes, marginRange, rng)
+		wall := render.Wall{Width: arrangement.Config.Wall.Width, Height: arrangement.Config.Wall.Height}
+		outputPath := outputPathFor(cfg.OutputPath, wallCfg)
+		if err := renderer.Render(wall, renderFrames, outputPath); err != nil {
+			fmt.Println("Error rendering:", err)
+			os.Exit(1)
+		}
 
-	visualize(wall, placedFrames)
+		fmt.Println("Visualization saved as", outputPath)
+	}
 }
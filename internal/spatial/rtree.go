@@ -0,0 +1,455 @@
+// Package spatial provides axis-aligned bounding box indexes used to
+// accelerate overlap queries during frame placement. A linear scan over
+// every placed frame is O(n) per candidate, which makes placement overall
+// O(n^2); an R-tree turns that lookup into roughly O(log n).
+package spatial
+
+import "sort"
+
+// Rect is an axis-aligned bounding box using inclusive min and exclusive
+// max coordinates, matching how frame positions and sizes are expressed.
+type Rect struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// Overlaps reports whether r and o share any area.
+func (r Rect) Overlaps(o Rect) bool {
+	return r.MinX < o.MaxX && r.MaxX > o.MinX &&
+		r.MinY < o.MaxY && r.MaxY > o.MinY
+}
+
+func (r Rect) area() float64 {
+	return (r.MaxX - r.MinX) * (r.MaxY - r.MinY)
+}
+
+// union returns the smallest rect containing both r and o.
+func (r Rect) union(o Rect) Rect {
+	return Rect{
+		MinX: min(r.MinX, o.MinX),
+		MinY: min(r.MinY, o.MinY),
+		MaxX: max(r.MaxX, o.MaxX),
+		MaxY: max(r.MaxY, o.MaxY),
+	}
+}
+
+func min(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Item is a single bounds + opaque ID pair stored in an Index. The ID is
+// caller-defined (e.g. an index into a frame slice) and is never
+// interpreted by the index itself.
+type Item struct {
+	ID     int
+	Bounds Rect
+}
+
+// Index is implemented by anything that can answer "which items overlap
+// this rectangle" faster than a linear scan.
+type Index interface {
+	// Insert adds a single item to the index.
+	Insert(item Item)
+	// BulkLoad replaces the index contents, built in one pass from items.
+	// It is cheaper than inserting one at a time for large initial sets.
+	BulkLoad(items []Item)
+	// Query returns the IDs of every item whose bounds overlap bounds.
+	Query(bounds Rect) []int
+	// Len reports how many items are currently indexed.
+	Len() int
+}
+
+// maxEntries bounds the fan-out of internal R-tree nodes. Nodes split
+// once they would exceed it.
+const maxEntries = 8
+
+// minEntries is the standard R-tree minimum fill for a non-root node
+// (the 40% rule). The splits below do not enforce it: a split can leave
+// a node with fewer entries than this.
+const minEntries = maxEntries * 2 / 5
+
+// RTree is a simple R-tree spatial index supporting incremental Insert
+// and STR (sort-tile-recursive) BulkLoad.
+type RTree struct {
+	root *node
+}
+
+type node struct {
+	leaf     bool
+	bounds   Rect
+	entries  []entry
+	children []*node
+}
+
+type entry struct {
+	bounds Rect
+	item   Item
+}
+
+// NewRTree returns an empty R-tree ready for Insert or BulkLoad.
+func NewRTree() *RTree {
+	return &RTree{root: &node{leaf: true}}
+}
+
+func (t *RTree) Len() int {
+	return countItems(t.root)
+}
+
+func countItems(n *node) int {
+	if n == nil {
+		return 0
+	}
+	if n.leaf {
+		return len(n.entries)
+	}
+	total := 0
+	for _, c := range n.children {
+		total += countItems(c)
+	}
+	return total
+}
+
+// Insert adds a single item, splitting nodes along the way as needed.
+func (t *RTree) Insert(item Item) {
+	if t.root == nil {
+		t.root = &node{leaf: true}
+	}
+	split := t.insert(t.root, entry{bounds: item.Bounds, item: item})
+	if split != nil {
+		newRoot := &node{children: []*node{t.root, split}}
+		newRoot.bounds = t.root.bounds.union(split.bounds)
+		t.root = newRoot
+	}
+}
+
+// insert recursively descends to a leaf, inserting e, and returns a
+// sibling node if the visited node had to split.
+func (t *RTree) insert(n *node, e entry) *node {
+	// An empty node's bounds start at the zero Rect, which (unlike an
+	// identity element) has real area at the origin; unioning into it
+	// directly would incorrectly pull every node's bounds toward (0,0)
+	// instead of starting from e's own bounds.
+	if len(n.entries) == 0 && len(n.children) == 0 {
+		n.bounds = e.bounds
+	} else {
+		n.bounds = n.bounds.union(e.bounds)
+	}
+
+	if n.leaf {
+		n.entries = append(n.entries, e)
+		if len(n.entries) > maxEntries {
+			return splitLeaf(n)
+		}
+		return nil
+	}
+
+	best := chooseSubtree(n, e.bounds)
+	split := t.insert(n.children[best], e)
+	if split != nil {
+		n.children = append(n.children, split)
+		if len(n.children) > maxEntries {
+			return splitInternal(n)
+		}
+	}
+	return nil
+}
+
+// chooseSubtree picks the child whose bounding box needs the least area
+// enlargement to contain bounds, breaking ties by smaller area.
+func chooseSubtree(n *node, bounds Rect) int {
+	best := 0
+	bestEnlargement := -1.0
+	bestArea := -1.0
+	for i, c := range n.children {
+		enlarged := c.bounds.union(bounds)
+		enlargement := enlarged.area() - c.bounds.area()
+		if bestEnlargement < 0 || enlargement < bestEnlargement ||
+			(enlargement == bestEnlargement && c.bounds.area() < bestArea) {
+			best = i
+			bestEnlargement = enlargement
+			bestArea = c.bounds.area()
+		}
+	}
+	return best
+}
+
+// splitLeaf divides an overflowing leaf's entries into two using a
+// quadratic-cost pick-seeds heuristic, and returns the new sibling.
+func splitLeaf(n *node) *node {
+	entries := n.entries
+	seedA, seedB := pickSeedsEntries(entries)
+
+	groupA := []entry{entries[seedA]}
+	groupB := []entry{entries[seedB]}
+	boundsA := entries[seedA].bounds
+	boundsB := entries[seedB].bounds
+
+	for i, e := range entries {
+		if i == seedA || i == seedB {
+			continue
+		}
+		if assignToA(boundsA, boundsB, e.bounds, len(groupA), len(groupB)) {
+			groupA = append(groupA, e)
+			boundsA = boundsA.union(e.bounds)
+		} else {
+			groupB = append(groupB, e)
+			boundsB = boundsB.union(e.bounds)
+		}
+	}
+
+	n.entries = groupA
+	n.bounds = boundsA
+	return &node{leaf: true, entries: groupB, bounds: boundsB}
+}
+
+// splitInternal divides an overflowing internal node's children the same
+// way splitLeaf divides entries.
+func splitInternal(n *node) *node {
+	children := n.children
+	seedA, seedB := pickSeedsChildren(children)
+
+	groupA := []*node{children[seedA]}
+	groupB := []*node{children[seedB]}
+	boundsA := children[seedA].bounds
+	boundsB := children[seedB].bounds
+
+	for i, c := range children {
+		if i == seedA || i == seedB {
+			continue
+		}
+		if assignToA(boundsA, boundsB, c.bounds, len(groupA), len(groupB)) {
+			groupA = append(groupA, c)
+			boundsA = boundsA.union(c.bounds)
+		} else {
+			groupB = append(groupB, c)
+			boundsB = boundsB.union(c.bounds)
+		}
+	}
+
+	n.children = groupA
+	n.bounds = boundsA
+	return &node{children: groupB, bounds: boundsB}
+}
+
+// assignToA decides whether bounds should join group A or B, preferring
+// the group needing less enlargement and, as a tiebreaker, the smaller
+// group. It does not guarantee either group reaches minEntries.
+func assignToA(boundsA, boundsB, bounds Rect, sizeA, sizeB int) bool {
+	enlargeA := boundsA.union(bounds).area() - boundsA.area()
+	enlargeB := boundsB.union(bounds).area() - boundsB.area()
+	if enlargeA != enlargeB {
+		return enlargeA < enlargeB
+	}
+	return sizeA <= sizeB
+}
+
+// pickSeedsEntries finds the pair of entries that would waste the most
+// area if placed in the same group (the classic R-tree quadratic seed
+// selection).
+func pickSeedsEntries(entries []entry) (int, int) {
+	bestWaste := -1.0
+	bestA, bestB := 0, 1
+	for i := 0; i < len(entries); i++ {
+		for j := i + 1; j < len(entries); j++ {
+			combined := entries[i].bounds.union(entries[j].bounds)
+			waste := combined.area() - entries[i].bounds.area() - entries[j].bounds.area()
+			if waste > bestWaste {
+				bestWaste = waste
+				bestA, bestB = i, j
+			}
+		}
+	}
+	return bestA, bestB
+}
+
+func pickSeedsChildren(children []*node) (int, int) {
+	bestWaste := -1.0
+	bestA, bestB := 0, 1
+	for i := 0; i < len(children); i++ {
+		for j := i + 1; j < len(children); j++ {
+			combined := children[i].bounds.union(children[j].bounds)
+			waste := combined.area() - children[i].bounds.area() - children[j].bounds.area()
+			if waste > bestWaste {
+				bestWaste = waste
+				bestA, bestB = i, j
+			}
+		}
+	}
+	return bestA, bestB
+}
+
+// Query returns the IDs of every indexed item overlapping bounds.
+func (t *RTree) Query(bounds Rect) []int {
+	if t.root == nil {
+		return nil
+	}
+	var hits []int
+	queryNode(t.root, bounds, &hits)
+	return hits
+}
+
+func queryNode(n *node, bounds Rect, hits *[]int) {
+	if !n.bounds.Overlaps(bounds) && (len(n.entries) > 0 || len(n.children) > 0) {
+		return
+	}
+	if n.leaf {
+		for _, e := range n.entries {
+			if e.bounds.Overlaps(bounds) {
+				*hits = append(*hits, e.item.ID)
+			}
+		}
+		return
+	}
+	for _, c := range n.children {
+		if c.bounds.Overlaps(bounds) {
+			queryNode(c, bounds, hits)
+		}
+	}
+}
+
+// BulkLoad replaces the tree's contents using sort-tile-recursive (STR)
+// packing: items are sorted by X into vertical slices, each slice sorted
+// by Y and cut into leaf-sized tiles. This produces a well-balanced tree
+// in O(n log n) instead of n sequential inserts.
+func (t *RTree) BulkLoad(items []Item) {
+	if len(items) == 0 {
+		t.root = &node{leaf: true}
+		return
+	}
+	t.root = strBuild(items)
+}
+
+func strBuild(items []Item) *node {
+	leaves := strLeaves(items)
+	nodes := leaves
+	for len(nodes) > 1 {
+		nodes = strLevel(nodes)
+	}
+	return nodes[0]
+}
+
+// strLeaves packs items into leaf nodes of at most maxEntries items each
+// using the sort-tile-recursive slicing strategy.
+func strLeaves(items []Item) []*node {
+	n := len(items)
+	leafCount := (n + maxEntries - 1) / maxEntries
+	sliceCount := int(isqrt(leafCount))
+	if sliceCount < 1 {
+		sliceCount = 1
+	}
+	sliceSize := sliceCount * maxEntries
+
+	sorted := append([]Item(nil), items...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Bounds.MinX < sorted[j].Bounds.MinX })
+
+	var leaves []*node
+	for i := 0; i < len(sorted); i += sliceSize {
+		end := i + sliceSize
+		if end > len(sorted) {
+			end = len(sorted)
+		}
+		slice := sorted[i:end]
+		sort.Slice(slice, func(i, j int) bool { return slice[i].Bounds.MinY < slice[j].Bounds.MinY })
+
+		for j := 0; j < len(slice); j += maxEntries {
+			k := j + maxEntries
+			if k > len(slice) {
+				k = len(slice)
+			}
+			chunk := slice[j:k]
+			leaf := &node{leaf: true}
+			for i, it := range chunk {
+				leaf.entries = append(leaf.entries, entry{bounds: it.Bounds, item: it})
+				if i == 0 {
+					leaf.bounds = it.Bounds
+				} else {
+					leaf.bounds = leaf.bounds.union(it.Bounds)
+				}
+			}
+			leaves = append(leaves, leaf)
+		}
+	}
+	return leaves
+}
+
+// strLevel groups a level of nodes into parents of at most maxEntries
+// children, using the same slicing strategy as strLeaves.
+func strLevel(nodes []*node) []*node {
+	if len(nodes) <= maxEntries {
+		parent := &node{children: append([]*node(nil), nodes...)}
+		for i, c := range nodes {
+			if i == 0 {
+				parent.bounds = c.bounds
+			} else {
+				parent.bounds = parent.bounds.union(c.bounds)
+			}
+		}
+		return []*node{parent}
+	}
+
+	n := len(nodes)
+	groupCount := (n + maxEntries - 1) / maxEntries
+	sliceCount := int(isqrt(groupCount))
+	if sliceCount < 1 {
+		sliceCount = 1
+	}
+	sliceSize := sliceCount * maxEntries
+
+	sorted := append([]*node(nil), nodes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].bounds.MinX < sorted[j].bounds.MinX })
+
+	var parents []*node
+	for i := 0; i < len(sorted); i += sliceSize {
+		end := i + sliceSize
+		if end > len(sorted) {
+			end = len(sorted)
+		}
+		slice := sorted[i:end]
+		sort.Slice(slice, func(i, j int) bool { return slice[i].bounds.MinY < slice[j].bounds.MinY })
+
+		for j := 0; j < len(slice); j += maxEntries {
+			k := j + maxEntries
+			if k > len(slice) {
+				k = len(slice)
+			}
+			chunk := slice[j:k]
+			parent := &node{children: append([]*node(nil), chunk...)}
+			for i, c := range chunk {
+				if i == 0 {
+					parent.bounds = c.bounds
+				} else {
+					parent.bounds = parent.bounds.union(c.bounds)
+				}
+			}
+			parents = append(parents, parent)
+		}
+	}
+	return parents
+}
+
+// isqrt returns the integer square root rounded down (and 1 for n <= 1),
+// used to pick a roughly square slice count for STR packing.
+func isqrt(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	x := n
+	for x*x > n {
+		x = (x + n/x) / 2
+	}
+	for (x+1)*(x+1) <= n {
+		x++
+	}
+	return x
+}
+
+var _ Index = (*RTree)(nil)
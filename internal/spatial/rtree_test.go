@@ -0,0 +1,125 @@
+package spatial
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// linearQuery is the O(n) oracle Query is meant to speed up: every item
+// whose bounds overlap bounds, in item order.
+func linearQuery(items []Item, bounds Rect) []int {
+	var hits []int
+	for _, it := range items {
+		if it.Bounds.Overlaps(bounds) {
+			hits = append(hits, it.ID)
+		}
+	}
+	return hits
+}
+
+func sortedInts(ids []int) []int {
+	sorted := append([]int(nil), ids...)
+	sort.Ints(sorted)
+	return sorted
+}
+
+func randomItems(n int, rng *rand.Rand) []Item {
+	items := make([]Item, n)
+	for i := range items {
+		x := float64(rng.Intn(1000))
+		y := float64(rng.Intn(1000))
+		w := float64(1 + rng.Intn(50))
+		h := float64(1 + rng.Intn(50))
+		items[i] = Item{ID: i, Bounds: Rect{MinX: x, MinY: y, MaxX: x + w, MaxY: y + h}}
+	}
+	return items
+}
+
+// TestRTreeInsertMatchesLinearScan builds a tree via Insert and checks
+// every query against the linear-scan oracle across random queries.
+func TestRTreeInsertMatchesLinearScan(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	items := randomItems(200, rng)
+
+	tree := NewRTree()
+	for _, it := range items {
+		tree.Insert(it)
+	}
+	if tree.Len() != len(items) {
+		t.Fatalf("Len() = %d, want %d", tree.Len(), len(items))
+	}
+
+	for i := 0; i < 100; i++ {
+		x := float64(rng.Intn(1000))
+		y := float64(rng.Intn(1000))
+		query := Rect{MinX: x, MinY: y, MaxX: x + 30, MaxY: y + 30}
+
+		got := sortedInts(tree.Query(query))
+		want := sortedInts(linearQuery(items, query))
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("query %d (%v): got %v, want %v", i, query, got, want)
+		}
+	}
+}
+
+// TestRTreeBulkLoadMatchesLinearScan is the same oracle check, but for
+// the STR BulkLoad path instead of one-at-a-time Insert.
+func TestRTreeBulkLoadMatchesLinearScan(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	items := randomItems(200, rng)
+
+	tree := NewRTree()
+	tree.BulkLoad(items)
+	if tree.Len() != len(items) {
+		t.Fatalf("Len() = %d, want %d", tree.Len(), len(items))
+	}
+
+	for i := 0; i < 100; i++ {
+		x := float64(rng.Intn(1000))
+		y := float64(rng.Intn(1000))
+		query := Rect{MinX: x, MinY: y, MaxX: x + 30, MaxY: y + 30}
+
+		got := sortedInts(tree.Query(query))
+		want := sortedInts(linearQuery(items, query))
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("query %d (%v): got %v, want %v", i, query, got, want)
+		}
+	}
+}
+
+// TestRTreeBoundsExcludeOrigin guards against the zero-value Rect bug: an
+// empty node's bounds used to start as Rect{0,0,0,0} and get unioned
+// against real items, so a tree built entirely from items far from the
+// origin ended up with root bounds incorrectly reaching back to (0,0).
+// That didn't break Query correctness (covered above), but it defeated
+// the bounds-overlap pruning Query relies on for speed.
+func TestRTreeBoundsExcludeOrigin(t *testing.T) {
+	far := Rect{MinX: 500, MinY: 500, MaxX: 510, MaxY: 510}
+
+	t.Run("Insert", func(t *testing.T) {
+		tree := NewRTree()
+		tree.Insert(Item{ID: 0, Bounds: far})
+		if tree.root.bounds.MinX == 0 || tree.root.bounds.MinY == 0 {
+			t.Errorf("root bounds = %+v, want bounds excluding the origin", tree.root.bounds)
+		}
+	})
+
+	t.Run("BulkLoad", func(t *testing.T) {
+		tree := NewRTree()
+		tree.BulkLoad([]Item{{ID: 0, Bounds: far}})
+		if tree.root.bounds.MinX == 0 || tree.root.bounds.MinY == 0 {
+			t.Errorf("root bounds = %+v, want bounds excluding the origin", tree.root.bounds)
+		}
+	})
+}
+
+// TestRTreeQueryEmpty checks that querying a freshly created tree with no
+// items doesn't panic and returns no hits.
+func TestRTreeQueryEmpty(t *testing.T) {
+	tree := NewRTree()
+	if hits := tree.Query(Rect{MaxX: 100, MaxY: 100}); len(hits) != 0 {
+		t.Errorf("Query on an empty tree = %v, want none", hits)
+	}
+}
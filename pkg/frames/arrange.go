@@ -0,0 +1,117 @@
+package frames
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"time"
+)
+
+// runtimeOptions bundles everything Option can configure. It is passed
+// down to Packer implementations so they can report progress without
+// every Pack signature growing a parameter per option.
+type runtimeOptions struct {
+	logger   *slog.Logger
+	observer PlacementObserver
+}
+
+// Option configures an Arrange call.
+type Option func(*runtimeOptions)
+
+// WithLogger routes placement progress through l instead of the default
+// slog.Default(). Arrange never writes to stdout directly.
+func WithLogger(l *slog.Logger) Option {
+	return func(o *runtimeOptions) { o.logger = l }
+}
+
+// WithObserver registers ob to receive placement callbacks (attempts,
+// placements, coverage updates, stalls).
+func WithObserver(ob PlacementObserver) Option {
+	return func(o *runtimeOptions) { o.observer = ob }
+}
+
+func resolveOptions(opts []Option) runtimeOptions {
+	o := runtimeOptions{
+		logger:   slog.Default(),
+		observer: noopObserver{},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Arrangement is the result of Arrange: the config it was built from (so
+// the run can be replayed or re-validated later), the placed frames, and
+// bookkeeping about how well they fit.
+type Arrangement struct {
+	Config     Config
+	Frames     []Frame
+	Efficiency float64
+	Unplaced   int
+}
+
+// Arrange selects cfg's packer, places frames onto cfg.Wall, optionally
+// settles them, and returns the result. It honors ctx cancellation: a
+// long-running packer (notably RandomNeighborPacker, whose loop has no
+// other termination guarantee) checks ctx between passes and returns
+// ctx.Err() instead of hanging forever.
+func Arrange(ctx context.Context, cfg Config, opts ...Option) (Arrangement, error) {
+	rt := resolveOptions(opts)
+
+	packer, err := NewPacker(cfg.Packer)
+	if err != nil {
+		return Arrangement{}, err
+	}
+
+	// A zero Seed means "pick one": resolve it now and write it back into
+	// cfg so the Arrangement (and anything that later serializes or
+	// Replays it) records the seed that actually produced these frames,
+	// not the zero sentinel that requested it.
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(cfg.Seed))
+
+	result, err := packer.Pack(ctx, cfg, rng, rt)
+	if err != nil {
+		return Arrangement{
+			Config:     cfg,
+			Frames:     result.Frames,
+			Efficiency: result.Efficiency,
+			Unplaced:   result.Unplaced,
+		}, err
+	}
+
+	placed := result.Frames
+	if cfg.Settle != "" {
+		settled, err := Settle(ctx, placed, cfg.Wall, cfg.Settle)
+		if err != nil {
+			return Arrangement{
+				Config:     cfg,
+				Frames:     settled,
+				Efficiency: result.Efficiency,
+				Unplaced:   result.Unplaced,
+			}, err
+		}
+		placed = settled
+	}
+
+	return Arrangement{
+		Config:     cfg,
+		Frames:     placed,
+		Efficiency: result.Efficiency,
+		Unplaced:   result.Unplaced,
+	}, nil
+}
+
+// Replay reconstructs an arrangement purely from cfg and seed: it sets
+// cfg.Seed and runs Arrange with a background context, so the same
+// (cfg, seed) pair always yields the same frame positions. It's the
+// inverse of persisting an Arrangement's Config and Frames via JSON —
+// given just the config, Replay regenerates the layout rather than
+// restoring it.
+func Replay(cfg Config, seed int64) (Arrangement, error) {
+	cfg.Seed = seed
+	return Arrange(context.Background(), cfg)
+}
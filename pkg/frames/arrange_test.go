@@ -0,0 +1,200 @@
+package frames
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// discardLogger silences Arrange's progress logging in tests so a run
+// with many placement passes doesn't flood test output.
+func discardLogger() Option {
+	return WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// zeroSeedMaxRectsConfig uses MaxRectsPacker (bounded by construction,
+// unlike RandomNeighborPacker's best-effort loop) with Seed left at its
+// zero-value default, so these tests exercise seed resolution without
+// depending on RandomNeighborPacker's own convergence guarantees.
+func zeroSeedMaxRectsConfig() Config {
+	cfg := DefaultConfig()
+	cfg.Packer = "maxrects"
+	cfg.Seed = 0
+	return cfg
+}
+
+// TestArrangeRecordsResolvedSeed reproduces the maintainer's report: with
+// Config.Seed left at its zero-value default, Arrange picked a
+// time-based seed internally but never wrote it back, so the persisted
+// Config.Seed stayed 0 and Replay(cfg, cfg.Seed) couldn't reproduce the
+// run. The returned Arrangement must record the seed actually used.
+func TestArrangeRecordsResolvedSeed(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	arr, err := Arrange(ctx, zeroSeedMaxRectsConfig(), discardLogger())
+	if err != nil {
+		t.Fatalf("Arrange: %v", err)
+	}
+	if arr.Config.Seed == 0 {
+		t.Fatal("Arrange returned an Arrangement with Config.Seed still 0")
+	}
+}
+
+// TestReplayReproducesArrangement checks that replaying a persisted
+// (Config, Seed) pair yields the exact same frame positions, which is
+// the whole point of recording the resolved seed.
+func TestReplayReproducesArrangement(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	original, err := Arrange(ctx, zeroSeedMaxRectsConfig(), discardLogger())
+	if err != nil {
+		t.Fatalf("Arrange: %v", err)
+	}
+
+	replayed, err := Replay(original.Config, original.Config.Seed)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+
+	if !reflect.DeepEqual(original.Frames, replayed.Frames) {
+		t.Errorf("Replay produced different frames:\noriginal: %+v\nreplayed: %+v", original.Frames, replayed.Frames)
+	}
+}
+
+// TestArrangeStopsOnContextCancellation checks the central promise behind
+// threading ctx through Arrange/Pack: RandomNeighborPacker's loop has no
+// termination guarantee of its own, so a cancelled context must make
+// Arrange return promptly with ctx.Err() instead of looping until the
+// (here unreachable) coverage target is hit.
+func TestArrangeStopsOnContextCancellation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Packer = "random-neighbor"
+	cfg.CoverageTarget = 0.99 // unreachable by the default catalog
+	cfg.Seed = 1
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // already cancelled before Arrange's loop gets to check it
+
+	done := make(chan struct{})
+	var arr Arrangement
+	var err error
+	go func() {
+		arr, err = Arrange(ctx, cfg, discardLogger())
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Arrange did not return promptly after context cancellation")
+	}
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Arrange error = %v, want context.Canceled", err)
+	}
+	if len(arr.Frames) == 0 {
+		t.Error("Arrange returned no frames at all; expected at least the first frame placed before cancellation was observed")
+	}
+}
+
+// recordingObserver is a PlacementObserver that counts each callback, so
+// tests can assert the callbacks actually fire instead of trusting that
+// Pack calls them.
+type recordingObserver struct {
+	mu              sync.Mutex
+	attempts        int
+	placements      int
+	coverageUpdates int
+	stalls          int
+}
+
+func (o *recordingObserver) OnFrameAttempt(Frame) {
+	o.mu.Lock()
+	o.attempts++
+	o.mu.Unlock()
+}
+
+func (o *recordingObserver) OnFramePlaced(Frame) {
+	o.mu.Lock()
+	o.placements++
+	o.mu.Unlock()
+}
+
+func (o *recordingObserver) OnCoverageUpdate(float64) {
+	o.mu.Lock()
+	o.coverageUpdates++
+	o.mu.Unlock()
+}
+
+func (o *recordingObserver) OnStall(string) {
+	o.mu.Lock()
+	o.stalls++
+	o.mu.Unlock()
+}
+
+// TestArrangeFiresObserverCallbacks checks that a successful run drives
+// OnFrameAttempt, OnFramePlaced, and OnCoverageUpdate through a
+// registered PlacementObserver, the other half of this request alongside
+// ctx cancellation.
+func TestArrangeFiresObserverCallbacks(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	obs := &recordingObserver{}
+	_, err := Arrange(ctx, zeroSeedMaxRectsConfig(), discardLogger(), WithObserver(obs))
+	if err != nil {
+		t.Fatalf("Arrange: %v", err)
+	}
+
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+	if obs.attempts == 0 {
+		t.Error("OnFrameAttempt never fired")
+	}
+	if obs.placements == 0 {
+		t.Error("OnFramePlaced never fired")
+	}
+	if obs.coverageUpdates == 0 {
+		t.Error("OnCoverageUpdate never fired")
+	}
+}
+
+// TestRandomNeighborPackerFiresOnStall checks that a pass which places
+// nothing reports OnStall: a wall with no room left for a second 8x8
+// frame next to the first forces every pass to stall until ctx bounds it.
+func TestRandomNeighborPackerFiresOnStall(t *testing.T) {
+	cfg := Config{
+		Wall: Wall{Width: 10, Height: 10},
+		Frames: []FrameSpec{
+			{Name: "big", Width: 8, Height: 8, Count: 3},
+		},
+		MarginRange:    [2]int{0, 0},
+		CoverageTarget: 0.99,
+		Packer:         "random-neighbor",
+		Seed:           1,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	obs := &recordingObserver{}
+	rt := resolveOptions([]Option{discardLogger(), WithObserver(obs)})
+	_, err := (RandomNeighborPacker{}).Pack(ctx, cfg, rand.New(rand.NewSource(cfg.Seed)), rt)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Pack error = %v, want context.DeadlineExceeded", err)
+	}
+
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+	if obs.stalls == 0 {
+		t.Error("OnStall never fired despite no room for a second frame")
+	}
+}
@@ -0,0 +1,26 @@
+package frames
+
+import "github.com/claudioscheer/frame-arrangement/internal/spatial"
+
+// hasCollision checks if newFrame overlaps any existing frame, using
+// index to narrow the candidates down from every placed frame to just
+// the ones whose (margin-expanded) bounds are nearby.
+func hasCollision(newFrame Frame, placed []Frame, margin int, index *spatial.RTree) bool {
+	query := spatial.Rect{
+		MinX: float64(newFrame.X - margin),
+		MinY: float64(newFrame.Y - margin),
+		MaxX: float64(newFrame.X + newFrame.Width + margin),
+		MaxY: float64(newFrame.Y + newFrame.Height + margin),
+	}
+
+	for _, id := range index.Query(query) {
+		frame := placed[id]
+		if newFrame.X < frame.X+frame.Width+margin &&
+			newFrame.X+newFrame.Width+margin > frame.X &&
+			newFrame.Y < frame.Y+frame.Height+margin &&
+			newFrame.Y+newFrame.Height+margin > frame.Y {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+// Package frames implements gallery-wall frame placement: given a wall
+// and a catalog of frame sizes, it arranges non-overlapping frames to hit
+// a target coverage, optionally settles them against an edge, and hands
+// back an Arrangement ready to render.
+package frames
+
+import (
+	"image/color"
+	"strconv"
+
+	"github.com/claudioscheer/frame-arrangement/internal/spatial"
+	"github.com/claudioscheer/frame-arrangement/render"
+)
+
+// Wall is the rectangular surface frames are placed on.
+type Wall struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Frame is a single placed frame: its size, position, and render style.
+type Frame struct {
+	Width  int          `json:"width"`
+	Height int          `json:"height"`
+	X      int          `json:"x"`
+	Y      int          `json:"y"`
+	Style  render.Style `json:"style"`
+
+	// aspectTolerance carries FrameSpec.AspectTolerance through expandFrames
+	// for a Packer that supports rotation to consult; it's placement
+	// bookkeeping, not part of a frame's identity, so it's unexported and
+	// excluded from Arrangement's JSON encoding.
+	aspectTolerance float64
+}
+
+func (f Frame) bounds() spatial.Rect {
+	return spatial.Rect{
+		MinX: float64(f.X),
+		MinY: float64(f.Y),
+		MaxX: float64(f.X + f.Width),
+		MaxY: float64(f.Y + f.Height),
+	}
+}
+
+// FrameSpec describes a named entry in a frame catalog: a frame size, how
+// many copies of it should be placed, how much its aspect ratio is
+// allowed to drift when a packer considers rotating it, and the visual
+// style every instance gets.
+type FrameSpec struct {
+	Name            string  `json:"name"`
+	Width           int     `json:"width"`
+	Height          int     `json:"height"`
+	Count           int     `json:"count"`
+	AspectTolerance float64 `json:"aspectTolerance"`
+
+	// Style fields are all optional; zero values fall back to the
+	// renderer's own defaults (see cmd's toRenderFrame for the CLI path).
+	MatColor        string `json:"matColor"`    // "#rrggbb"
+	BorderColor     string `json:"borderColor"` // "#rrggbb"
+	BorderThickness int    `json:"borderThickness"`
+	ShadowColor     string `json:"shadowColor"` // "#rrggbb", presence enables the shadow
+	ShadowOffsetX   int    `json:"shadowOffsetX"`
+	ShadowOffsetY   int    `json:"shadowOffsetY"`
+	ShadowBlur      int    `json:"shadowBlur"`
+	Texture         string `json:"texture"`
+	Label           string `json:"label"`
+}
+
+// style builds the render.Style this spec describes, using hex colors
+// like "#rrggbb" and leaving unset fields at their zero value.
+func (s FrameSpec) style() render.Style {
+	return render.Style{
+		MatColor:        parseHexColor(s.MatColor),
+		BorderColor:     parseHexColor(s.BorderColor),
+		BorderThickness: s.BorderThickness,
+		ShadowColor:     parseHexColor(s.ShadowColor),
+		ShadowOffsetX:   s.ShadowOffsetX,
+		ShadowOffsetY:   s.ShadowOffsetY,
+		ShadowBlur:      s.ShadowBlur,
+		Texture:         s.Texture,
+		Label:           s.Label,
+	}
+}
+
+// parseHexColor parses a "#rrggbb" string into an opaque color.RGBA,
+// returning the zero color (fully transparent) for an empty or
+// malformed string.
+func parseHexColor(hex string) color.RGBA {
+	if len(hex) != 7 || hex[0] != '#' {
+		return color.RGBA{}
+	}
+	v, err := strconv.ParseUint(hex[1:], 16, 32)
+	if err != nil {
+		return color.RGBA{}
+	}
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 255}
+}
+
+// Config holds everything needed to arrange frames: the wall, the frame
+// catalog, placement margins/coverage, the packer to use, the RNG seed,
+// and an optional settle direction.
+type Config struct {
+	Wall           Wall        `json:"wall"`
+	Frames         []FrameSpec `json:"frames"`
+	MarginRange    [2]int      `json:"marginRange"`
+	CoverageTarget float64     `json:"coverageTarget"`
+	Seed           int64       `json:"seed"`
+	// Packer selects the placement strategy: "random-neighbor" (default)
+	// or "maxrects". See NewPacker.
+	Packer string `json:"packer"`
+	// Settle, if non-empty, runs a gravity-style post-process pass after
+	// placement: "down", "up", "left", "right", or "center". Empty skips
+	// settling and keeps the packer's raw output.
+	Settle Direction `json:"settle"`
+}
+
+// DefaultConfig returns the arrangement this package used to have baked
+// into main before it became configurable.
+func DefaultConfig() Config {
+	return Config{
+		Wall: Wall{Width: 230, Height: 140},
+		Frames: []FrameSpec{
+			{Name: "portrait-small", Width: 10, Height: 15, Count: 1},
+			{Name: "landscape-small", Width: 15, Height: 10, Count: 1},
+			{Name: "portrait-medium", Width: 13, Height: 18, Count: 1},
+			{Name: "landscape-medium", Width: 18, Height: 13, Count: 1},
+			{Name: "landscape-wide", Width: 16, Height: 9, Count: 1},
+			{Name: "square", Width: 9, Height: 9, Count: 1},
+		},
+		MarginRange:    [2]int{2, 5},
+		CoverageTarget: 0.54,
+		Seed:           0,
+		Packer:         "random-neighbor",
+	}
+}
+
+// expandFrames flattens the frame catalog into the flat per-instance
+// slice a Packer works over, repeating each spec Count times (a Count of
+// zero or less yields one copy).
+func (c Config) expandFrames() []Frame {
+	var result []Frame
+	for _, spec := range c.Frames {
+		count := spec.Count
+		if count <= 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			result = append(result, Frame{Width: spec.Width, Height: spec.Height, Style: spec.style(), aspectTolerance: spec.AspectTolerance})
+		}
+	}
+	return result
+}
@@ -0,0 +1,30 @@
+package frames
+
+// PlacementObserver lets a caller watch a Packer work, e.g. to drive a
+// UI, render an animated GIF of the placement process, or decide when to
+// give up and cancel the context passed to Arrange.
+type PlacementObserver interface {
+	// OnFrameAttempt fires before a Packer tries to place f. The first
+	// frame RandomNeighborPacker drops at a random position gets no
+	// attempt callback, only OnFramePlaced.
+	OnFrameAttempt(f Frame)
+	// OnFramePlaced fires once f has been placed at its final position.
+	OnFramePlaced(f Frame)
+	// OnCoverageUpdate reports the current covered-area ratio:
+	// MaxRectsPacker calls it after every placement, RandomNeighborPacker
+	// at the start of every pass (so the same ratio can repeat).
+	OnCoverageUpdate(ratio float64)
+	// OnStall fires when RandomNeighborPacker completes a full pass over
+	// the frame catalog without placing anything. MaxRectsPacker makes a
+	// single pass and counts frames that don't fit as Unplaced instead.
+	OnStall(reason string)
+}
+
+// noopObserver is the default PlacementObserver: it does nothing, so
+// Arrange callers that don't care about progress don't pay for it.
+type noopObserver struct{}
+
+func (noopObserver) OnFrameAttempt(Frame)     {}
+func (noopObserver) OnFramePlaced(Frame)      {}
+func (noopObserver) OnCoverageUpdate(float64) {}
+func (noopObserver) OnStall(string)           {}
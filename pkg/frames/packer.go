@@ -0,0 +1,345 @@
+package frames
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/claudioscheer/frame-arrangement/internal/spatial"
+)
+
+// PlacementResult is what a Packer produces: the placed frames plus
+// enough bookkeeping to judge the result without re-deriving it (how much
+// of the wall ended up covered, and how many frames didn't fit).
+type PlacementResult struct {
+	Frames     []Frame
+	Efficiency float64 // covered area / wall area
+	Unplaced   int
+}
+
+// Packer is a pluggable frame placement strategy. It checks ctx for
+// cancellation and reports progress through rt's logger and observer.
+type Packer interface {
+	Pack(ctx context.Context, cfg Config, rng *rand.Rand, rt runtimeOptions) (PlacementResult, error)
+}
+
+// NewPacker resolves a packer by its config name.
+func NewPacker(name string) (Packer, error) {
+	switch name {
+	case "", "random-neighbor":
+		return RandomNeighborPacker{}, nil
+	case "maxrects":
+		return MaxRectsPacker{}, nil
+	default:
+		return nil, fmt.Errorf("unknown packer %q", name)
+	}
+}
+
+// effectiveMargin returns the minimum gap Verify should require between
+// frames for an Arrangement built from cfg. Only RandomNeighborPacker
+// actually spaces frames apart by MarginRange; MaxRectsPacker packs them
+// edge-to-edge, and a Settle pass deliberately rests frames flush against
+// a neighbor (see Settle's doc comment), so both legitimately produce a
+// zero gap regardless of what MarginRange says.
+func effectiveMargin(cfg Config) int {
+	if cfg.Settle != "" {
+		return 0
+	}
+	switch cfg.Packer {
+	case "", "random-neighbor":
+		return cfg.MarginRange[0]
+	default:
+		return 0
+	}
+}
+
+// RandomNeighborPacker is the original heuristic: place the first frame
+// randomly, then place each subsequent frame in a free-looking slot next
+// to an already-placed one, until the coverage target is hit. It has no
+// termination guarantee of its own — ctx is what bounds it.
+type RandomNeighborPacker struct{}
+
+func (RandomNeighborPacker) Pack(ctx context.Context, cfg Config, rng *rand.Rand, rt runtimeOptions) (PlacementResult, error) {
+	wall := cfg.Wall
+	frameSizes := cfg.expandFrames()
+	marginRange := cfg.MarginRange
+
+	if len(frameSizes) == 0 {
+		return PlacementResult{}, fmt.Errorf("random-neighbor packer: config has no frames to place")
+	}
+	if marginRange[1] < marginRange[0] {
+		return PlacementResult{}, fmt.Errorf("random-neighbor packer: margin range %v has max below min", marginRange)
+	}
+
+	var placedFrames []Frame
+	requiredArea := int(float64(wall.Width*wall.Height) * cfg.CoverageTarget)
+	totalArea := 0
+	index := spatial.NewRTree()
+
+	// Place the first frame randomly on the wall. rng.Intn panics on a
+	// non-positive argument, which a frame as wide/tall as the wall (or
+	// wider, via a typo'd or deliberately full-width spec) would trigger.
+	firstFrame := frameSizes[0]
+	if firstFrame.Width >= wall.Width || firstFrame.Height >= wall.Height {
+		return PlacementResult{}, fmt.Errorf("random-neighbor packer: frame %dx%d does not fit on %dx%d wall", firstFrame.Width, firstFrame.Height, wall.Width, wall.Height)
+	}
+	firstFrame.X, firstFrame.Y = rng.Intn(wall.Width-firstFrame.Width), rng.Intn(wall.Height-firstFrame.Height)
+	placedFrames = append(placedFrames, firstFrame)
+	totalArea += firstFrame.Width * firstFrame.Height
+	index.Insert(spatial.Item{ID: 0, Bounds: firstFrame.bounds()})
+	rt.observer.OnFramePlaced(firstFrame)
+
+	// Place subsequent frames near previous frames with some random offset
+	for totalArea < requiredArea {
+		select {
+		case <-ctx.Done():
+			return PlacementResult{
+				Frames:     placedFrames,
+				Efficiency: float64(totalArea) / float64(wall.Width*wall.Height),
+			}, ctx.Err()
+		default:
+		}
+
+		coverage := float64(totalArea) / float64(wall.Width*wall.Height)
+		rt.logger.Info("placement progress", "coverage", coverage, "placed", len(placedFrames))
+		rt.observer.OnCoverageUpdate(coverage)
+
+		rng.Shuffle(len(frameSizes), func(i, j int) {
+			frameSizes[i], frameSizes[j] = frameSizes[j], frameSizes[i]
+		})
+
+		placedThisPass := false
+
+		for _, frame := range frameSizes {
+			if totalArea >= requiredArea {
+				break
+			}
+
+			rt.observer.OnFrameAttempt(frame)
+			// rng.Intn(0) panics, so a fixed margin (marginRange[0] ==
+			// marginRange[1]) skips the randomized draw instead of
+			// crashing on an otherwise perfectly valid config.
+			margin := marginRange[0]
+			if marginRange[1] > marginRange[0] {
+				margin = rng.Intn(marginRange[1]-marginRange[0]) + marginRange[0]
+			}
+			placed := false
+
+			// Try to place the frame near each existing frame with more candidate positions
+			for _, prevFrame := range placedFrames {
+				// Generate 12 potential positions around the existing frame
+				candidates := []Frame{
+					{Width: frame.Width, Height: frame.Height, X: prevFrame.X - frame.Width - margin, Y: prevFrame.Y, Style: frame.Style},                                 // Left
+					{Width: frame.Width, Height: frame.Height, X: prevFrame.X + prevFrame.Width + margin, Y: prevFrame.Y, Style: frame.Style},                             // Right
+					{Width: frame.Width, Height: frame.Height, X: prevFrame.X, Y: prevFrame.Y - frame.Height - margin, Style: frame.Style},                                // Above
+					{Width: frame.Width, Height: frame.Height, X: prevFrame.X, Y: prevFrame.Y + prevFrame.Height + margin, Style: frame.Style},                            // Below
+					{Width: frame.Width, Height: frame.Height, X: prevFrame.X - frame.Width - margin, Y: prevFrame.Y - margin, Style: frame.Style},                        // Top-left
+					{Width: frame.Width, Height: frame.Height, X: prevFrame.X + prevFrame.Width + margin, Y: prevFrame.Y - margin, Style: frame.Style},                    // Top-right
+					{Width: frame.Width, Height: frame.Height, X: prevFrame.X - frame.Width - margin, Y: prevFrame.Y + prevFrame.Height + margin, Style: frame.Style},     // Bottom-left
+					{Width: frame.Width, Height: frame.Height, X: prevFrame.X + prevFrame.Width + margin, Y: prevFrame.Y + prevFrame.Height + margin, Style: frame.Style}, // Bottom-right
+					{Width: frame.Width, Height: frame.Height, X: prevFrame.X - frame.Width, Y: prevFrame.Y - frame.Height, Style: frame.Style},                           // Top-left diagonal
+					{Width: frame.Width, Height: frame.Height, X: prevFrame.X + prevFrame.Width, Y: prevFrame.Y - frame.Height, Style: frame.Style},                       // Top-right diagonal
+					{Width: frame.Width, Height: frame.Height, X: prevFrame.X - frame.Width, Y: prevFrame.Y + prevFrame.Height, Style: frame.Style},                       // Bottom-left diagonal
+					{Width: frame.Width, Height: frame.Height, X: prevFrame.X + prevFrame.Width, Y: prevFrame.Y + prevFrame.Height, Style: frame.Style},                   // Bottom-right diagonal
+				}
+
+				// Shuffle the candidates and try placing them
+				rng.Shuffle(len(candidates), func(i, j int) {
+					candidates[i], candidates[j] = candidates[j], candidates[i]
+				})
+
+				for _, candidate := range candidates {
+					// Check bounds and collisions
+					if candidate.X >= 0 && candidate.Y >= 0 &&
+						candidate.X+candidate.Width <= wall.Width &&
+						candidate.Y+candidate.Height <= wall.Height &&
+						!hasCollision(candidate, placedFrames, margin, index) {
+						index.Insert(spatial.Item{ID: len(placedFrames), Bounds: candidate.bounds()})
+						placedFrames = append(placedFrames, candidate)
+						totalArea += candidate.Width * candidate.Height
+						placed = true
+						placedThisPass = true
+						rt.observer.OnFramePlaced(candidate)
+						break
+					}
+				}
+
+				if placed {
+					break
+				}
+			}
+
+			// If no valid position was found, try another frame
+			if !placed {
+				continue
+			}
+		}
+
+		if !placedThisPass {
+			rt.observer.OnStall("full pass over the frame catalog placed nothing")
+		}
+	}
+
+	return PlacementResult{
+		Frames:     placedFrames,
+		Efficiency: float64(totalArea) / float64(wall.Width*wall.Height),
+	}, nil
+}
+
+// freeRectangle is a maximal empty area the MAXRECTS packer can still
+// place frames into.
+type freeRectangle struct {
+	x, y, w, h int
+}
+
+func (r freeRectangle) contains(o freeRectangle) bool {
+	return o.x >= r.x && o.y >= r.y &&
+		o.x+o.w <= r.x+r.w && o.y+o.h <= r.y+r.h
+}
+
+func (r freeRectangle) equals(o freeRectangle) bool {
+	return r.x == o.x && r.y == o.y && r.w == o.w && r.h == o.h
+}
+
+// MaxRectsPacker places frames by maintaining the list of maximal free
+// rectangles on the wall. For each frame it scores every free rectangle
+// with Best-Short-Side-Fit, places the frame in the best-scoring fit
+// (trying both orientations when rotation helps), and splits that
+// rectangle into the leftover space to its right and below.
+type MaxRectsPacker struct{}
+
+func (MaxRectsPacker) Pack(ctx context.Context, cfg Config, rng *rand.Rand, rt runtimeOptions) (PlacementResult, error) {
+	wall := cfg.Wall
+	catalog := cfg.expandFrames()
+	rng.Shuffle(len(catalog), func(i, j int) {
+		catalog[i], catalog[j] = catalog[j], catalog[i]
+	})
+
+	free := []freeRectangle{{x: 0, y: 0, w: wall.Width, h: wall.Height}}
+	var placed []Frame
+	unplaced := 0
+	placedArea := 0
+
+	for _, f := range catalog {
+		select {
+		case <-ctx.Done():
+			return PlacementResult{
+				Frames:     placed,
+				Efficiency: float64(placedArea) / float64(wall.Width*wall.Height),
+				Unplaced:   unplaced,
+			}, ctx.Err()
+		default:
+		}
+
+		rt.observer.OnFrameAttempt(f)
+
+		bestIdx := -1
+		bestRotated := false
+		bestScore := 0
+		haveBest := false
+
+		for i, fr := range free {
+			if score, ok := bssfScore(fr, f.Width, f.Height); ok && (!haveBest || score < bestScore) {
+				bestIdx, bestRotated, bestScore, haveBest = i, false, score, true
+			}
+			if f.Width != f.Height && allowsRotation(f) {
+				if score, ok := bssfScore(fr, f.Height, f.Width); ok && (!haveBest || score < bestScore) {
+					bestIdx, bestRotated, bestScore, haveBest = i, true, score, true
+				}
+			}
+		}
+
+		if !haveBest {
+			unplaced++
+			continue
+		}
+
+		fr := free[bestIdx]
+		w, h := f.Width, f.Height
+		if bestRotated {
+			w, h = f.Height, f.Width
+		}
+
+		placedFrame := Frame{Width: w, Height: h, X: fr.x, Y: fr.y, Style: f.Style}
+		placed = append(placed, placedFrame)
+		placedArea += w * h
+		rt.observer.OnFramePlaced(placedFrame)
+		rt.observer.OnCoverageUpdate(float64(placedArea) / float64(wall.Width*wall.Height))
+
+		free = append(free[:bestIdx], free[bestIdx+1:]...)
+		if rightW := fr.w - w; rightW > 0 {
+			free = append(free, freeRectangle{x: fr.x + w, y: fr.y, w: rightW, h: h})
+		}
+		if belowH := fr.h - h; belowH > 0 {
+			free = append(free, freeRectangle{x: fr.x, y: fr.y + h, w: fr.w, h: belowH})
+		}
+		free = pruneContainedRects(free)
+	}
+
+	rt.logger.Info("maxrects packing done",
+		"placed", len(placed), "unplaced", unplaced,
+		"efficiency", float64(placedArea)/float64(wall.Width*wall.Height))
+
+	return PlacementResult{
+		Frames:     placed,
+		Efficiency: float64(placedArea) / float64(wall.Width*wall.Height),
+		Unplaced:   unplaced,
+	}, nil
+}
+
+// allowsRotation reports whether f is close enough to square for
+// MaxRectsPacker to consider placing it rotated: FrameSpec.AspectTolerance
+// bounds how far (f.Width-f.Height)/max(f.Width,f.Height) may drift from
+// 0 (a perfect square) before swapping the two would visibly distort the
+// frame beyond what the catalog entry allows.
+func allowsRotation(f Frame) bool {
+	longer := math.Max(float64(f.Width), float64(f.Height))
+	if longer == 0 {
+		return true
+	}
+	deviation := math.Abs(float64(f.Width-f.Height)) / longer
+	return deviation <= f.aspectTolerance
+}
+
+// bssfScore returns the Best-Short-Side-Fit score for fitting a w x h
+// frame into fr: the smaller of the two leftover dimensions. A lower
+// score is a tighter fit. ok is false if the frame doesn't fit at all.
+func bssfScore(fr freeRectangle, w, h int) (int, bool) {
+	if w > fr.w || h > fr.h {
+		return 0, false
+	}
+	leftoverW := fr.w - w
+	leftoverH := fr.h - h
+	if leftoverW < leftoverH {
+		return leftoverW, true
+	}
+	return leftoverH, true
+}
+
+// pruneContainedRects drops free rectangles that are fully contained in
+// another, which the right-of/below-of split naturally produces over
+// time. Exact duplicates are collapsed to a single copy.
+func pruneContainedRects(rects []freeRectangle) []freeRectangle {
+	var pruned []freeRectangle
+	for i, a := range rects {
+		redundant := false
+		for j, b := range rects {
+			if i == j {
+				continue
+			}
+			if !b.contains(a) {
+				continue
+			}
+			if a.equals(b) && j > i {
+				continue // keep the first copy of an exact duplicate
+			}
+			redundant = true
+			break
+		}
+		if !redundant {
+			pruned = append(pruned, a)
+		}
+	}
+	return pruned
+}
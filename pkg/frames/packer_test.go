@@ -0,0 +1,289 @@
+package frames
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// TestBSSFScorePicksTighterFit checks the scoring function the MAXRECTS
+// placement loop relies on directly: the lower score is the free
+// rectangle that leaves less slack on its shorter leftover side, and a
+// frame too big for a rectangle in either dimension doesn't fit at all.
+func TestBSSFScorePicksTighterFit(t *testing.T) {
+	fr := freeRectangle{x: 0, y: 0, w: 10, h: 10}
+
+	looseScore, ok := bssfScore(fr, 2, 2)
+	if !ok || looseScore != 8 {
+		t.Fatalf("bssfScore(10x10, 2x2) = %d, %v, want 8, true", looseScore, ok)
+	}
+
+	tightScore, ok := bssfScore(fr, 9, 4)
+	if !ok || tightScore != 1 {
+		t.Fatalf("bssfScore(10x10, 9x4) = %d, %v, want 1, true", tightScore, ok)
+	}
+	if tightScore >= looseScore {
+		t.Errorf("tighter fit scored %d, want lower than the loose fit's %d", tightScore, looseScore)
+	}
+
+	if _, ok := bssfScore(fr, 11, 1); ok {
+		t.Error("bssfScore(10x10, 11x1) reported a fit, want false (too wide)")
+	}
+}
+
+// TestMaxRectsSplitsFreeRectangle checks the free-rectangle bookkeeping
+// through Pack's results: a lone frame lands in the wall's corner, and a
+// second frame still fits into the leftover space the split leaves
+// behind.
+func TestMaxRectsSplitsFreeRectangle(t *testing.T) {
+	cfg := Config{
+		Wall: Wall{Width: 10, Height: 10},
+		Frames: []FrameSpec{
+			{Name: "corner", Width: 4, Height: 3, Count: 1},
+		},
+		CoverageTarget: 0,
+		Packer:         "maxrects",
+		Seed:           1,
+	}
+
+	result, err := (MaxRectsPacker{}).Pack(context.Background(), cfg, rand.New(rand.NewSource(cfg.Seed)), resolveOptions(nil))
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+	if len(result.Frames) != 1 || result.Unplaced != 0 {
+		t.Fatalf("got %d placed / %d unplaced, want the single frame placed", len(result.Frames), result.Unplaced)
+	}
+	f := result.Frames[0]
+	if f.X != 0 || f.Y != 0 || f.Width != 4 || f.Height != 3 {
+		t.Fatalf("placed frame = %+v, want it in the 10x10 wall's corner at 4x3", f)
+	}
+
+	// A second, larger frame can only fit in the leftover space if the
+	// split actually happened: the 6-wide x 3-tall strip right of the
+	// first frame doesn't fit a 7x7 frame, but the 10-wide x 7-tall strip
+	// below the first frame does.
+	cfg.Frames = append(cfg.Frames, FrameSpec{Name: "below", Width: 7, Height: 7, Count: 1})
+	result, err = (MaxRectsPacker{}).Pack(context.Background(), cfg, rand.New(rand.NewSource(cfg.Seed)), resolveOptions(nil))
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+	if len(result.Frames) != 2 || result.Unplaced != 0 {
+		t.Fatalf("got %d placed / %d unplaced, want both frames placed using the split leftover space", len(result.Frames), result.Unplaced)
+	}
+}
+
+// TestMaxRectsReportsUnplacedWhenFrameDoesNotFit checks that a frame
+// bigger than the wall in both orientations is counted as unplaced
+// rather than silently dropped or placed overlapping.
+func TestMaxRectsReportsUnplacedWhenFrameDoesNotFit(t *testing.T) {
+	cfg := Config{
+		Wall: Wall{Width: 5, Height: 5},
+		Frames: []FrameSpec{
+			{Name: "too-big", Width: 10, Height: 10, Count: 1},
+		},
+		CoverageTarget: 0,
+		Packer:         "maxrects",
+		Seed:           1,
+	}
+
+	result, err := (MaxRectsPacker{}).Pack(context.Background(), cfg, rand.New(rand.NewSource(cfg.Seed)), resolveOptions(nil))
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+	if len(result.Frames) != 0 || result.Unplaced != 1 {
+		t.Fatalf("got %d placed / %d unplaced, want the oversized frame rejected", len(result.Frames), result.Unplaced)
+	}
+}
+
+// TestRandomNeighborPackerRespectsMargin checks RandomNeighborPacker's
+// placement loop end to end: every pair of placed frames must clear the
+// configured MarginRange, and the run must stop once CoverageTarget is
+// hit rather than running away (ctx bounds it, but a healthy run never
+// needs that backstop).
+func TestRandomNeighborPackerRespectsMargin(t *testing.T) {
+	cfg := Config{
+		Wall: Wall{Width: 100, Height: 100},
+		Frames: []FrameSpec{
+			{Name: "small", Width: 10, Height: 10, Count: 6},
+		},
+		MarginRange:    [2]int{2, 4},
+		CoverageTarget: 0.1,
+		Packer:         "random-neighbor",
+		Seed:           1,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := (RandomNeighborPacker{}).Pack(ctx, cfg, rand.New(rand.NewSource(cfg.Seed)), resolveOptions(nil))
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+	if len(result.Frames) < 2 {
+		t.Fatalf("got %d placed frames, want at least 2 to check margins between them", len(result.Frames))
+	}
+
+	for i, a := range result.Frames {
+		for j, b := range result.Frames {
+			if i >= j {
+				continue
+			}
+			if rectsOverlapWithin(a, b, cfg.MarginRange[0]) {
+				t.Errorf("frames %d and %d are closer than the minimum margin %d: %+v, %+v", i, j, cfg.MarginRange[0], a, b)
+			}
+		}
+	}
+}
+
+// TestRandomNeighborPackerRejectsOversizedFirstFrame reproduces the
+// maintainer's report: a frame as wide/tall as (or wider/taller than) the
+// wall made rng.Intn(wall.Width-firstFrame.Width) panic instead of
+// returning an error, so a perfectly plausible "full-width" frame spec
+// (or a typo'd one) took down the whole CLI.
+func TestRandomNeighborPackerRejectsOversizedFirstFrame(t *testing.T) {
+	cfg := Config{
+		Wall: Wall{Width: 50, Height: 50},
+		Frames: []FrameSpec{
+			{Name: "full-width", Width: 50, Height: 10, Count: 1},
+		},
+		MarginRange:    [2]int{2, 4},
+		CoverageTarget: 0.1,
+		Packer:         "random-neighbor",
+		Seed:           1,
+	}
+
+	_, err := (RandomNeighborPacker{}).Pack(context.Background(), cfg, rand.New(rand.NewSource(cfg.Seed)), resolveOptions(nil))
+	if err == nil {
+		t.Fatal("expected an error for a frame that does not fit on the wall, got nil")
+	}
+}
+
+// TestRandomNeighborPackerRejectsEmptyCatalog reproduces the maintainer's
+// report that an empty Config.Frames made frameSizes[0] panic with
+// "index out of range" instead of returning an error.
+func TestRandomNeighborPackerRejectsEmptyCatalog(t *testing.T) {
+	cfg := Config{
+		Wall:           Wall{Width: 50, Height: 50},
+		MarginRange:    [2]int{2, 4},
+		CoverageTarget: 0.1,
+		Packer:         "random-neighbor",
+		Seed:           1,
+	}
+
+	_, err := (RandomNeighborPacker{}).Pack(context.Background(), cfg, rand.New(rand.NewSource(cfg.Seed)), resolveOptions(nil))
+	if err == nil {
+		t.Fatal("expected an error for an empty frame catalog, got nil")
+	}
+}
+
+// TestRandomNeighborPackerAllowsFixedMargin reproduces the maintainer's
+// report: MarginRange[1] == MarginRange[0] (a fixed, non-random margin, a
+// perfectly reasonable config) made rng.Intn(0) panic instead of simply
+// using that fixed margin.
+func TestRandomNeighborPackerAllowsFixedMargin(t *testing.T) {
+	cfg := Config{
+		Wall: Wall{Width: 100, Height: 100},
+		Frames: []FrameSpec{
+			{Name: "small", Width: 10, Height: 10, Count: 6},
+		},
+		MarginRange:    [2]int{3, 3},
+		CoverageTarget: 0.1,
+		Packer:         "random-neighbor",
+		Seed:           1,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := (RandomNeighborPacker{}).Pack(ctx, cfg, rand.New(rand.NewSource(cfg.Seed)), resolveOptions(nil))
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+	if len(result.Frames) == 0 {
+		t.Fatal("expected at least one placed frame")
+	}
+}
+
+// TestRandomNeighborPackerRejectsSwappedMarginRange reproduces the
+// maintainer's report that a swapped/typo'd MarginRange (max below min)
+// made rng.Intn panic with "invalid argument to Intn" instead of
+// returning an error.
+func TestRandomNeighborPackerRejectsSwappedMarginRange(t *testing.T) {
+	cfg := Config{
+		Wall: Wall{Width: 100, Height: 100},
+		Frames: []FrameSpec{
+			{Name: "small", Width: 10, Height: 10, Count: 6},
+		},
+		MarginRange:    [2]int{5, 2},
+		CoverageTarget: 0.1,
+		Packer:         "random-neighbor",
+		Seed:           1,
+	}
+
+	_, err := (RandomNeighborPacker{}).Pack(context.Background(), cfg, rand.New(rand.NewSource(cfg.Seed)), resolveOptions(nil))
+	if err == nil {
+		t.Fatal("expected an error for a swapped margin range, got nil")
+	}
+}
+
+// rectsOverlapWithin reports whether a and b come within margin of each
+// other (i.e. the gap between them, in at least one axis, is smaller
+// than margin would allow).
+func rectsOverlapWithin(a, b Frame, margin int) bool {
+	return a.X < b.X+b.Width+margin && a.X+a.Width+margin > b.X &&
+		a.Y < b.Y+b.Height+margin && a.Y+a.Height+margin > b.Y
+}
+
+// TestMaxRectsRespectsAspectTolerance reproduces the maintainer's report:
+// FrameSpec.AspectTolerance was parsed from config but never consulted,
+// so MaxRectsPacker would rotate any non-square frame regardless of how
+// much that distorts its catalog-intended look. A frame whose tolerance
+// is 0 (far from square, rotation not allowed) must only ever land in a
+// free rectangle wide enough for its original (unrotated) dimensions.
+func TestMaxRectsRespectsAspectTolerance(t *testing.T) {
+	cfg := Config{
+		Wall: Wall{Width: 20, Height: 100},
+		Frames: []FrameSpec{
+			// 90 wide x 5 tall only fits the 20x100 wall rotated (5 wide x
+			// 90 tall); AspectTolerance 0 must forbid that rotation.
+			{Name: "wide", Width: 90, Height: 5, Count: 1, AspectTolerance: 0},
+		},
+		CoverageTarget: 0,
+		Packer:         "maxrects",
+		Seed:           1,
+	}
+
+	result, err := (MaxRectsPacker{}).Pack(context.Background(), cfg, rand.New(rand.NewSource(cfg.Seed)), resolveOptions(nil))
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+	if len(result.Frames) != 0 || result.Unplaced != 1 {
+		t.Fatalf("got %d placed / %d unplaced, want the frame rejected rather than rotated", len(result.Frames), result.Unplaced)
+	}
+}
+
+// TestMaxRectsRotatesWithinTolerance checks the inverse: a high enough
+// AspectTolerance still lets MaxRectsPacker use rotation to make a frame
+// fit, same as before this field was wired in.
+func TestMaxRectsRotatesWithinTolerance(t *testing.T) {
+	cfg := Config{
+		Wall: Wall{Width: 20, Height: 100},
+		Frames: []FrameSpec{
+			{Name: "wide", Width: 90, Height: 5, Count: 1, AspectTolerance: 1},
+		},
+		CoverageTarget: 0,
+		Packer:         "maxrects",
+		Seed:           1,
+	}
+
+	result, err := (MaxRectsPacker{}).Pack(context.Background(), cfg, rand.New(rand.NewSource(cfg.Seed)), resolveOptions(nil))
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+	if len(result.Frames) != 1 || result.Unplaced != 0 {
+		t.Fatalf("got %d placed / %d unplaced, want the frame placed rotated to fit", len(result.Frames), result.Unplaced)
+	}
+	f := result.Frames[0]
+	if f.Width != 5 || f.Height != 90 {
+		t.Errorf("placed frame = %dx%d, want the rotated 5x90 orientation", f.Width, f.Height)
+	}
+}
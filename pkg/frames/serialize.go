@@ -0,0 +1,38 @@
+package frames
+
+import "encoding/json"
+
+// arrangementJSON mirrors Arrangement's fields under an explicit schema,
+// so the on-disk format doesn't silently shift if Arrangement's Go fields
+// are ever reordered or renamed.
+type arrangementJSON struct {
+	Config     Config  `json:"config"`
+	Frames     []Frame `json:"frames"`
+	Efficiency float64 `json:"efficiency"`
+	Unplaced   int     `json:"unplaced"`
+}
+
+// MarshalJSON persists an Arrangement's config, seed, and resulting frame
+// positions, so a run can be archived, diffed, or handed to Verify/Replay
+// later without re-running the placement.
+func (a Arrangement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(arrangementJSON{
+		Config:     a.Config,
+		Frames:     a.Frames,
+		Efficiency: a.Efficiency,
+		Unplaced:   a.Unplaced,
+	})
+}
+
+// UnmarshalJSON restores an Arrangement previously written by MarshalJSON.
+func (a *Arrangement) UnmarshalJSON(data []byte) error {
+	var raw arrangementJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	a.Config = raw.Config
+	a.Frames = raw.Frames
+	a.Efficiency = raw.Efficiency
+	a.Unplaced = raw.Unplaced
+	return nil
+}
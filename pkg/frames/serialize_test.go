@@ -0,0 +1,109 @@
+package frames
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestArrangementRoundTripsThroughJSON checks serialize.go's headline
+// promise: a run's config, seed, and resulting frame positions survive a
+// marshal/unmarshal cycle intact, so an archived Arrangement can be
+// reloaded and re-verified without re-running placement.
+func TestArrangementRoundTripsThroughJSON(t *testing.T) {
+	cfg := Config{
+		Wall: Wall{Width: 50, Height: 50},
+		Frames: []FrameSpec{
+			{Name: "small", Width: 10, Height: 10, Count: 4},
+		},
+		CoverageTarget: 0.2,
+		Packer:         "maxrects",
+		Seed:           1,
+	}
+
+	arr, err := Arrange(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Arrange: %v", err)
+	}
+	if err := Verify(arr); err != nil {
+		t.Fatalf("Verify(arr) before round-trip: %v", err)
+	}
+
+	data, err := json.Marshal(arr)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var restored Arrangement
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if err := Verify(restored); err != nil {
+		t.Fatalf("Verify(restored) after round-trip: %v", err)
+	}
+	if !reflect.DeepEqual(arr, restored) {
+		t.Fatalf("round-tripped Arrangement differs from the original:\ngot:  %+v\nwant: %+v", restored, arr)
+	}
+}
+
+// TestArrangementJSONUsesLowerCamelCaseThroughout checks that Frame and
+// render.Style carry json tags like the rest of Config, instead of
+// falling back to bare Go field names ("Width", "Style.MatColor") in the
+// same archived file that uses "width"/"matColor" everywhere else.
+func TestArrangementJSONUsesLowerCamelCaseThroughout(t *testing.T) {
+	cfg := Config{
+		Wall: Wall{Width: 50, Height: 50},
+		Frames: []FrameSpec{
+			{Name: "small", Width: 10, Height: 10, Count: 1, MatColor: "#112233"},
+		},
+		CoverageTarget: 0.1,
+		Packer:         "maxrects",
+		Seed:           1,
+	}
+
+	arr, err := Arrange(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Arrange: %v", err)
+	}
+
+	data, err := json.Marshal(arr)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Unmarshal into map: %v", err)
+	}
+
+	frameList, ok := raw["frames"].([]interface{})
+	if !ok || len(frameList) == 0 {
+		t.Fatalf("frames = %v, want a non-empty array", raw["frames"])
+	}
+	frame, ok := frameList[0].(map[string]interface{})
+	if !ok {
+		t.Fatalf("frames[0] = %v, want an object", frameList[0])
+	}
+
+	for _, key := range []string{"width", "height", "x", "y", "style"} {
+		if _, ok := frame[key]; !ok {
+			t.Errorf("frames[0] missing lowerCamelCase key %q, got keys %v", key, frame)
+		}
+	}
+	if _, ok := frame["Width"]; ok {
+		t.Errorf("frames[0] has bare Go field name %q, want only lowerCamelCase keys", "Width")
+	}
+
+	style, ok := frame["style"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("frames[0].style = %v, want an object", frame["style"])
+	}
+	if _, ok := style["matColor"]; !ok {
+		t.Errorf("frames[0].style missing lowerCamelCase key %q, got keys %v", "matColor", style)
+	}
+	if _, ok := style["MatColor"]; ok {
+		t.Errorf("frames[0].style has bare Go field name %q, want only lowerCamelCase keys", "MatColor")
+	}
+}
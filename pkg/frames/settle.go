@@ -0,0 +1,228 @@
+package frames
+
+import (
+	"context"
+
+	"github.com/claudioscheer/frame-arrangement/internal/spatial"
+)
+
+// Direction is the edge (or point) frames are pulled toward during a
+// Settle pass.
+type Direction string
+
+const (
+	DirectionDown         Direction = "down"
+	DirectionUp           Direction = "up"
+	DirectionLeft         Direction = "left"
+	DirectionRight        Direction = "right"
+	DirectionCenterOfMass Direction = "center"
+)
+
+// Settle runs an optional gravity-style post-process over an already
+// placed arrangement: each frame repeatedly moves toward direction by the
+// largest step that doesn't collide with another frame or leave the
+// wall, until a full pass moves nothing. This turns free-floating
+// placements into visually grounded ones (e.g. all frames resting on a
+// bottom rail). It honors ctx cancellation between passes the same way a
+// Packer does, since nothing else bounds the loop if a future direction
+// or step calculation turns out not to converge.
+func Settle(ctx context.Context, placed []Frame, wall Wall, direction Direction) ([]Frame, error) {
+	settled := append([]Frame(nil), placed...)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return settled, ctx.Err()
+		default:
+		}
+
+		// One bulk-loaded index per pass, not per frame: blockingDistance
+		// excludes a frame from colliding against itself by skipping its
+		// own ID in the query results (see its id == i check) rather than
+		// needing a freshly rebuilt index with that frame left out. A
+		// frame that moves gets re-inserted at its new bounds so later
+		// frames in the same pass see it where it actually is; the stale
+		// entry left behind is a harmless duplicate (same ID, so it's
+		// skipped or dead code path).
+		index := spatial.NewRTree()
+		items := make([]spatial.Item, len(settled))
+		for id, f := range settled {
+			items[id] = spatial.Item{ID: id, Bounds: f.bounds()}
+		}
+		index.BulkLoad(items)
+
+		moved := false
+		for i := range settled {
+			if settleOne(settled, i, wall, direction, index) {
+				moved = true
+				index.Insert(spatial.Item{ID: i, Bounds: settled[i].bounds()})
+			}
+		}
+		if !moved {
+			break
+		}
+	}
+
+	return settled, nil
+}
+
+// settleOne moves frames[i] one step toward direction, mutating it in
+// place, and reports whether it actually moved.
+func settleOne(frames []Frame, i int, wall Wall, direction Direction, index *spatial.RTree) bool {
+	var dx, dy int
+
+	// maxStepX/maxStepY cap the center-of-mass case to the actual distance
+	// remaining to the target, so a frame that's closer than the nearest
+	// obstacle stops at the target instead of overshooting past it (and
+	// potentially reversing direction forever on the next pass).
+	maxStepX, maxStepY := -1, -1
+
+	switch direction {
+	case DirectionDown:
+		dx, dy = 0, 1
+	case DirectionUp:
+		dx, dy = 0, -1
+	case DirectionLeft:
+		dx, dy = -1, 0
+	case DirectionRight:
+		dx, dy = 1, 0
+	case DirectionCenterOfMass:
+		cx, cy := centerOfMass(frames, i)
+		f := frames[i]
+		fx, fy := float64(f.X)+float64(f.Width)/2, float64(f.Y)+float64(f.Height)/2
+		dx, dy = sign(cx-fx), sign(cy-fy)
+		maxStepX, maxStepY = int(abs(cx-fx)), int(abs(cy-fy))
+	default:
+		return false
+	}
+
+	moved := false
+	if dx != 0 {
+		step := blockingDistance(frames, i, wall, dx, 0, index)
+		if maxStepX >= 0 && maxStepX < step {
+			step = maxStepX
+		}
+		if step > 0 {
+			frames[i].X += dx * step
+			moved = true
+		}
+	}
+	if dy != 0 {
+		step := blockingDistance(frames, i, wall, 0, dy, index)
+		if maxStepY >= 0 && maxStepY < step {
+			step = maxStepY
+		}
+		if step > 0 {
+			frames[i].Y += dy * step
+			moved = true
+		}
+	}
+	return moved
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// centerOfMass returns the average center of every frame except i.
+func centerOfMass(frames []Frame, i int) (float64, float64) {
+	var sumX, sumY float64
+	count := 0
+	for id, f := range frames {
+		if id == i {
+			continue
+		}
+		sumX += float64(f.X) + float64(f.Width)/2
+		sumY += float64(f.Y) + float64(f.Height)/2
+		count++
+	}
+	if count == 0 {
+		f := frames[i]
+		return float64(f.X) + float64(f.Width)/2, float64(f.Y) + float64(f.Height)/2
+	}
+	return sumX / float64(count), sumY / float64(count)
+}
+
+func sign(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// blockingDistance returns the largest distance frames[i] can travel
+// along the (dx, dy) unit direction — exactly one of which is non-zero —
+// before it would overlap the wall edge or another frame's exact bounds
+// (no margin: a settled arrangement is allowed to rest flush against a
+// neighbor).
+func blockingDistance(frames []Frame, i int, wall Wall, dx, dy int, index *spatial.RTree) int {
+	f := frames[i]
+
+	var limit int
+	switch {
+	case dx == 1:
+		limit = wall.Width - (f.X + f.Width)
+	case dx == -1:
+		limit = f.X
+	case dy == 1:
+		limit = wall.Height - (f.Y + f.Height)
+	case dy == -1:
+		limit = f.Y
+	}
+	if limit <= 0 {
+		return 0
+	}
+
+	query := f.bounds()
+	switch {
+	case dx == 1:
+		query.MaxX = float64(wall.Width)
+	case dx == -1:
+		query.MinX = 0
+	case dy == 1:
+		query.MaxY = float64(wall.Height)
+	case dy == -1:
+		query.MinY = 0
+	}
+
+	for _, id := range index.Query(query) {
+		if id == i {
+			continue
+		}
+		o := frames[id]
+		var dist int
+		switch {
+		case dx == 1:
+			if o.X < f.X+f.Width || f.Y+f.Height <= o.Y || o.Y+o.Height <= f.Y {
+				continue
+			}
+			dist = o.X - (f.X + f.Width)
+		case dx == -1:
+			if o.X+o.Width > f.X || f.Y+f.Height <= o.Y || o.Y+o.Height <= f.Y {
+				continue
+			}
+			dist = f.X - (o.X + o.Width)
+		case dy == 1:
+			if o.Y < f.Y+f.Height || f.X+f.Width <= o.X || o.X+o.Width <= f.X {
+				continue
+			}
+			dist = o.Y - (f.Y + f.Height)
+		case dy == -1:
+			if o.Y+o.Height > f.Y || f.X+f.Width <= o.X || o.X+o.Width <= f.X {
+				continue
+			}
+			dist = f.Y - (o.Y + o.Height)
+		}
+		if dist < limit {
+			limit = dist
+		}
+	}
+	return limit
+}
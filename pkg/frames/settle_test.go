@@ -0,0 +1,89 @@
+package frames
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestSettleCenterOfMassTerminates reproduces the maintainer's repro: a
+// small random layout settled toward DirectionCenterOfMass used to
+// overshoot the target center every pass (since its step size came from
+// blockingDistance instead of the remaining distance to the center) and
+// could oscillate forever. It must now converge well within ctx's
+// deadline.
+func TestSettleCenterOfMassTerminates(t *testing.T) {
+	wall := Wall{Width: 300, Height: 300}
+	placed := []Frame{
+		{X: 10, Y: 10, Width: 30, Height: 20},
+		{X: 250, Y: 20, Width: 25, Height: 25},
+		{X: 40, Y: 250, Width: 20, Height: 30},
+		{X: 200, Y: 200, Width: 40, Height: 15},
+		{X: 120, Y: 120, Width: 15, Height: 15},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	settled, err := Settle(ctx, placed, wall, DirectionCenterOfMass)
+	if err != nil {
+		t.Fatalf("Settle did not converge: %v", err)
+	}
+	if len(settled) != len(placed) {
+		t.Fatalf("got %d frames, want %d", len(settled), len(placed))
+	}
+}
+
+// TestSettleCenterOfMassProducesNoOverlaps guards against a regression
+// from sharing one spatial index across a whole pass (instead of
+// rebuilding it per frame): a frame re-inserted at its new bounds after
+// moving must still block every frame settled later in the same pass, or
+// two frames could settle into overlapping positions.
+func TestSettleCenterOfMassProducesNoOverlaps(t *testing.T) {
+	wall := Wall{Width: 200, Height: 200}
+	placed := []Frame{
+		{X: 5, Y: 5, Width: 30, Height: 20},
+		{X: 170, Y: 10, Width: 25, Height: 25},
+		{X: 20, Y: 170, Width: 20, Height: 30},
+		{X: 150, Y: 150, Width: 40, Height: 15},
+		{X: 90, Y: 90, Width: 15, Height: 15},
+		{X: 60, Y: 140, Width: 20, Height: 20},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	settled, err := Settle(ctx, placed, wall, DirectionCenterOfMass)
+	if err != nil {
+		t.Fatalf("Settle did not converge: %v", err)
+	}
+
+	for i := 0; i < len(settled); i++ {
+		for j := i + 1; j < len(settled); j++ {
+			a, b := settled[i], settled[j]
+			if a.X < b.X+b.Width && a.X+a.Width > b.X && a.Y < b.Y+b.Height && a.Y+a.Height > b.Y {
+				t.Errorf("frames %d and %d overlap after settling: %+v, %+v", i, j, a, b)
+			}
+		}
+	}
+}
+
+// TestSettleDownRestsOnFloor checks the simple edge-direction case still
+// grounds every frame against the bottom wall or another frame.
+func TestSettleDownRestsOnFloor(t *testing.T) {
+	wall := Wall{Width: 100, Height: 100}
+	placed := []Frame{
+		{X: 0, Y: 0, Width: 20, Height: 20},
+		{X: 30, Y: 10, Width: 20, Height: 20},
+	}
+
+	settled, err := Settle(context.Background(), placed, wall, DirectionDown)
+	if err != nil {
+		t.Fatalf("Settle: %v", err)
+	}
+	for i, f := range settled {
+		if f.Y+f.Height != wall.Height {
+			t.Errorf("frame %d did not reach the floor: Y=%d Height=%d wall.Height=%d", i, f.Y, f.Height, wall.Height)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package frames
+
+import "fmt"
+
+// Verify checks that an Arrangement is physically valid: every frame
+// sits inside the wall, and no two frames are closer together than the
+// margin the arrangement was actually produced with (see
+// effectiveMargin). It exists so a persisted Arrangement (see
+// MarshalJSON) can be trusted after a round-trip through disk or a
+// network, without re-running the packer.
+func Verify(arr Arrangement) error {
+	wall := arr.Config.Wall
+	margin := effectiveMargin(arr.Config)
+
+	for i, f := range arr.Frames {
+		if f.X < 0 || f.Y < 0 || f.X+f.Width > wall.Width || f.Y+f.Height > wall.Height {
+			return fmt.Errorf("frame %d out of bounds: (%d,%d) %dx%d on a %dx%d wall",
+				i, f.X, f.Y, f.Width, f.Height, wall.Width, wall.Height)
+		}
+	}
+
+	for i := 0; i < len(arr.Frames); i++ {
+		for j := i + 1; j < len(arr.Frames); j++ {
+			a, b := arr.Frames[i], arr.Frames[j]
+			if a.X < b.X+b.Width+margin &&
+				a.X+a.Width+margin > b.X &&
+				a.Y < b.Y+b.Height+margin &&
+				a.Y+a.Height+margin > b.Y {
+				return fmt.Errorf("frames %d and %d overlap (or are closer than margin %d)", i, j, margin)
+			}
+		}
+	}
+
+	return nil
+}
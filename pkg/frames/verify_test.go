@@ -0,0 +1,62 @@
+package frames
+
+import (
+	"context"
+	"testing"
+)
+
+func maxRectsConfig() Config {
+	cfg := DefaultConfig()
+	cfg.Packer = "maxrects"
+	cfg.Seed = 1
+	return cfg
+}
+
+// TestVerifyAcceptsMaxRectsZeroMargin reproduces the maintainer's report:
+// Verify used to always apply MarginRange[0] regardless of how the
+// arrangement was produced, so a perfectly valid edge-to-edge MAXRECTS
+// packing failed verification.
+func TestVerifyAcceptsMaxRectsZeroMargin(t *testing.T) {
+	arr, err := Arrange(context.Background(), maxRectsConfig())
+	if err != nil {
+		t.Fatalf("Arrange: %v", err)
+	}
+	if err := Verify(arr); err != nil {
+		t.Errorf("Verify rejected a valid maxrects packing: %v", err)
+	}
+}
+
+// TestVerifyAcceptsSettledFlushNeighbors checks the same zero-margin
+// relaxation applies once frames have been settled, since Settle also
+// rests frames flush against each other on purpose.
+func TestVerifyAcceptsSettledFlushNeighbors(t *testing.T) {
+	cfg := maxRectsConfig()
+	cfg.Settle = DirectionDown
+	arr, err := Arrange(context.Background(), cfg, discardLogger())
+	if err != nil {
+		t.Fatalf("Arrange: %v", err)
+	}
+	if err := Verify(arr); err != nil {
+		t.Errorf("Verify rejected a valid settled packing: %v", err)
+	}
+}
+
+// TestVerifyStillEnforcesRandomNeighborMargin makes sure the relaxation
+// above didn't just make Verify permissive across the board: an
+// arrangement built with the default random-neighbor packer and no
+// settle should still reject two frames touching with no margin.
+func TestVerifyStillEnforcesRandomNeighborMargin(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MarginRange = [2]int{5, 6}
+	arr := Arrangement{
+		Config: cfg,
+		Frames: []Frame{
+			{X: 0, Y: 0, Width: 10, Height: 10},
+			{X: 10, Y: 0, Width: 10, Height: 10},
+		},
+	}
+
+	if err := Verify(arr); err == nil {
+		t.Error("Verify accepted two frames touching with no margin under random-neighbor config")
+	}
+}
@@ -0,0 +1,100 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+var labelColor = color.RGBA{R: 51, G: 51, B: 51, A: 255}
+
+var whiteColor = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+
+// PNGRenderer rasterizes frames as nested rectangles (shadow, border,
+// mat, content) using draw.Draw + image.Uniform fills rather than
+// per-pixel img.Set loops, and draws any Style.Label caption centered
+// below the frame.
+type PNGRenderer struct{}
+
+func (PNGRenderer) Render(wall Wall, frames []Frame, outputPath string) error {
+	img := image.NewRGBA(image.Rect(0, 0, wall.Width, wall.Height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: whiteColor}, image.Point{}, draw.Src)
+
+	for _, f := range frames {
+		drawShadow(img, f)
+		drawRect(img, f.X, f.Y, f.Width, f.Height, f.Style.BorderColor, draw.Src)
+
+		x, y, w, h := inset(f.X, f.Y, f.Width, f.Height, f.Style.BorderThickness)
+		drawRect(img, x, y, w, h, f.Style.MatColor, draw.Src)
+
+		// A fixed mat window margin keeps the content rect visually
+		// distinct from the mat even when BorderThickness is 0.
+		x, y, w, h = inset(x, y, w, h, matWindowMargin)
+		drawRect(img, x, y, w, h, f.Style.ContentColor, draw.Src)
+
+		if f.Style.Label != "" {
+			drawTextCentered(img, f.X+f.Width/2, f.Y+f.Height+12, f.Style.Label)
+		}
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return png.Encode(file, img)
+}
+
+const matWindowMargin = 2
+
+// drawShadow approximates a blurred drop shadow by expanding the offset
+// rectangle by ShadowBlur on every side and compositing it with draw.Over
+// so it blends under the frame instead of overwriting the background.
+func drawShadow(img *image.RGBA, f Frame) {
+	x := f.X + f.Style.ShadowOffsetX - f.Style.ShadowBlur
+	y := f.Y + f.Style.ShadowOffsetY - f.Style.ShadowBlur
+	w := f.Width + 2*f.Style.ShadowBlur
+	h := f.Height + 2*f.Style.ShadowBlur
+	drawRect(img, x, y, w, h, f.Style.ShadowColor, draw.Over)
+}
+
+// drawTextCentered stamps s using the small fixed-width bitmap font from
+// golang.org/x/image/font/basicfont, horizontally centered on cx with its
+// baseline at y — matching SVGRenderer's text-anchor="middle" label. That
+// font package ships its glyphs as Go source, so this needs no TrueType
+// font file or system font lookup.
+func drawTextCentered(img *image.RGBA, cx, y int, s string) {
+	d := font.Drawer{
+		Dst:  img,
+		Src:  &image.Uniform{C: labelColor},
+		Face: basicfont.Face7x13,
+	}
+	width := d.MeasureString(s)
+	d.Dot = fixed.P(cx, y)
+	d.Dot.X -= width / 2
+	d.DrawString(s)
+}
+
+// drawRect composites a c-filled rectangle, skipping the draw entirely
+// when c is fully transparent (the zero value of color.RGBA, meaning the
+// style field was left unset) so it leaves whatever's already in img
+// untouched instead of compositing a transparent hole through it with
+// draw.Src — matching SVGRenderer's fill="none" handling of the same
+// zero-value case.
+func drawRect(img *image.RGBA, x, y, w, h int, c color.RGBA, op draw.Op) {
+	if w <= 0 || h <= 0 || c.A == 0 {
+		return
+	}
+	rect := image.Rect(x, y, x+w, y+h).Intersect(img.Bounds())
+	if rect.Empty() {
+		return
+	}
+	draw.Draw(img, rect, &image.Uniform{C: c}, image.Point{}, op)
+}
@@ -0,0 +1,121 @@
+package render
+
+import (
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestPNGRendererSkipsZeroAlphaBorder checks that a frame with no
+// BorderColor set (the zero value, fully transparent) leaves the white
+// background visible instead of compositing a transparent ring through it
+// with draw.Src, matching SVGRenderer's "fill=none" behavior for the same
+// case.
+func TestPNGRendererSkipsZeroAlphaBorder(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "out.png")
+	frame := Frame{X: 10, Y: 10, Width: 40, Height: 40, Style: Style{
+		BorderThickness: 5,
+		MatColor:        color.RGBA{R: 0, G: 0, B: 255, A: 255},
+	}}
+
+	if err := (PNGRenderer{}).Render(Wall{Width: 60, Height: 60}, []Frame{frame}, outputPath); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	file, err := os.Open(outputPath)
+	if err != nil {
+		t.Fatalf("opening rendered PNG: %v", err)
+	}
+	defer file.Close()
+
+	img, err := png.Decode(file)
+	if err != nil {
+		t.Fatalf("decoding rendered PNG: %v", err)
+	}
+
+	got := color.RGBAModel.Convert(img.At(10, 10)).(color.RGBA)
+	if got != whiteColor {
+		t.Errorf("pixel in the border band = %v, want the untouched white background %v", got, whiteColor)
+	}
+}
+
+// TestPNGRendererSkipsZeroAlphaMatAndContent checks the same zero-alpha
+// skip for MatColor and ContentColor: DefaultConfig's catalog never sets
+// matColor, and a direct PNGRenderer caller (unlike cmd, which always
+// defaults ContentColor) can leave either unset. Each rect is drawn over
+// whatever the previous (larger) rect left behind, so skipping an unset
+// MatColor/ContentColor should leave the border color showing through
+// rather than punching a transparent hole down to the canvas.
+func TestPNGRendererSkipsZeroAlphaMatAndContent(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "out.png")
+	borderColor := color.RGBA{R: 10, G: 20, B: 30, A: 255}
+	frame := Frame{X: 10, Y: 10, Width: 40, Height: 40, Style: Style{
+		BorderColor:     borderColor,
+		BorderThickness: 5,
+	}}
+
+	if err := (PNGRenderer{}).Render(Wall{Width: 60, Height: 60}, []Frame{frame}, outputPath); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	file, err := os.Open(outputPath)
+	if err != nil {
+		t.Fatalf("opening rendered PNG: %v", err)
+	}
+	defer file.Close()
+
+	img, err := png.Decode(file)
+	if err != nil {
+		t.Fatalf("decoding rendered PNG: %v", err)
+	}
+
+	// (25, 25) sits inside both the mat and content insets, so with
+	// MatColor and ContentColor both unset it should still show the
+	// border color drawn underneath, not a transparent hole.
+	got := color.RGBAModel.Convert(img.At(25, 25)).(color.RGBA)
+	if got != borderColor {
+		t.Errorf("pixel inside the unset mat/content area = %v, want the border color %v showing through", got, borderColor)
+	}
+}
+
+// TestPNGRendererDrawsLabel checks that a Style.Label caption is actually
+// rasterized below the frame, matching SVGRenderer's <text> element
+// instead of being silently dropped for lack of a font dependency.
+func TestPNGRendererDrawsLabel(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "out.png")
+	frame := Frame{X: 5, Y: 5, Width: 40, Height: 40, Style: Style{
+		ContentColor: color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		Label:        "Living Room",
+	}}
+	wall := Wall{Width: 80, Height: 80}
+
+	if err := (PNGRenderer{}).Render(wall, []Frame{frame}, outputPath); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	file, err := os.Open(outputPath)
+	if err != nil {
+		t.Fatalf("opening rendered PNG: %v", err)
+	}
+	defer file.Close()
+
+	img, err := png.Decode(file)
+	if err != nil {
+		t.Fatalf("decoding rendered PNG: %v", err)
+	}
+
+	labelRow := frame.Y + frame.Height + 12
+	found := false
+	for x := frame.X; x < frame.X+frame.Width && x < wall.Width; x++ {
+		for y := labelRow - 10; y <= labelRow && y < wall.Height; y++ {
+			if got := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA); got != whiteColor {
+				found = true
+			}
+		}
+	}
+	if !found {
+		t.Error("expected non-background pixels near the label baseline, found none")
+	}
+}
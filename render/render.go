@@ -0,0 +1,69 @@
+// Package render turns a wall and a list of styled frames into an image.
+// It has no knowledge of how frames were placed — callers convert their
+// own frame type into render.Frame and pick a Renderer.
+package render
+
+import "image/color"
+
+// Wall is the canvas a set of frames is rendered onto.
+type Wall struct {
+	Width  int
+	Height int
+}
+
+// Style carries the per-frame visual metadata a Renderer honors: the mat
+// surrounding the content, the border (frame molding) around the mat, an
+// optional drop shadow, and optional texture/label metadata.
+type Style struct {
+	MatColor        color.RGBA `json:"matColor"`
+	ContentColor    color.RGBA `json:"contentColor"`
+	BorderColor     color.RGBA `json:"borderColor"`
+	BorderThickness int        `json:"borderThickness"`
+
+	ShadowColor   color.RGBA `json:"shadowColor"`
+	ShadowOffsetX int        `json:"shadowOffsetX"`
+	ShadowOffsetY int        `json:"shadowOffsetY"`
+	ShadowBlur    int        `json:"shadowBlur"`
+
+	// Texture, if set, names a fill pattern/image a renderer may use in
+	// place of ContentColor. Neither renderer below looks up real image
+	// data for it — SVGRenderer stamps it as a non-rendered
+	// data-texture attribute for callers with their own texture lookup,
+	// and PNGRenderer (having no raster equivalent of an element
+	// attribute) leaves it as config-only metadata.
+	Texture string `json:"texture"`
+	// Label, if set, is a caption drawn centered below the frame. Both
+	// renderers draw it: SVGRenderer as a <text> element, PNGRenderer
+	// using golang.org/x/image/font/basicfont's bitmap font.
+	Label string `json:"label"`
+}
+
+// Frame is a single positioned, styled rectangle to render.
+type Frame struct {
+	X, Y          int
+	Width, Height int
+	Style         Style
+}
+
+// Renderer draws a wall of frames to an output format.
+type Renderer interface {
+	// Render writes the rendered image/markup for wall and frames to
+	// outputPath, choosing the file format implied by the renderer.
+	Render(wall Wall, frames []Frame, outputPath string) error
+}
+
+// inset shrinks a rectangle by n on every side, clamping so it never
+// goes negative.
+func inset(x, y, w, h, n int) (int, int, int, int) {
+	x += n
+	y += n
+	w -= 2 * n
+	h -= 2 * n
+	if w < 0 {
+		w = 0
+	}
+	if h < 0 {
+		h = 0
+	}
+	return x, y, w, h
+}
@@ -0,0 +1,78 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"strings"
+)
+
+// SVGRenderer emits a scalable <rect>-based SVG suitable for print
+// mockups, with CSS styling for mat/border fills and an SVG filter for
+// the drop shadow.
+type SVGRenderer struct{}
+
+func (SVGRenderer) Render(wall Wall, frames []Frame, outputPath string) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		wall.Width, wall.Height, wall.Width, wall.Height)
+	b.WriteString(`<defs><filter id="frame-shadow" x="-50%" y="-50%" width="200%" height="200%">` +
+		`<feGaussianBlur in="SourceAlpha" stdDeviation="2"/>` +
+		`<feOffset dx="0" dy="0" result="offsetblur"/>` +
+		`<feMerge><feMergeNode/><feMergeNode in="SourceGraphic"/></feMerge>` +
+		`</filter></defs>` + "\n")
+	fmt.Fprintf(&b, `<rect x="0" y="0" width="%d" height="%d" fill="#ffffff"/>`+"\n", wall.Width, wall.Height)
+
+	for _, f := range frames {
+		writeFrame(&b, f)
+	}
+
+	b.WriteString("</svg>\n")
+
+	return os.WriteFile(outputPath, []byte(b.String()), 0o644)
+}
+
+func writeFrame(b *strings.Builder, f Frame) {
+	if f.Style.ShadowColor.A != 0 {
+		fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" filter="url(#frame-shadow)"/>`+"\n",
+			f.X+f.Style.ShadowOffsetX-f.Style.ShadowBlur, f.Y+f.Style.ShadowOffsetY-f.Style.ShadowBlur,
+			f.Width+2*f.Style.ShadowBlur, f.Height+2*f.Style.ShadowBlur, cssColor(f.Style.ShadowColor))
+	}
+
+	fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+		f.X, f.Y, f.Width, f.Height, cssColor(f.Style.BorderColor))
+
+	x, y, w, h := inset(f.X, f.Y, f.Width, f.Height, f.Style.BorderThickness)
+	fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+		x, y, w, h, cssColor(f.Style.MatColor))
+
+	x, y, w, h = inset(x, y, w, h, matWindowMargin)
+	contentAttrs := fmt.Sprintf(`fill="%s"`, cssColor(f.Style.ContentColor))
+	if f.Style.Texture != "" {
+		contentAttrs += fmt.Sprintf(` data-texture="%s"`, f.Style.Texture)
+	}
+	fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" %s/>`+"\n", x, y, w, h, contentAttrs)
+
+	if f.Style.Label != "" {
+		cx := f.X + f.Width/2
+		labelY := f.Y + f.Height + 12
+		fmt.Fprintf(b, `<text x="%d" y="%d" text-anchor="middle" font-size="10" fill="#333333">%s</text>`+"\n",
+			cx, labelY, escapeXML(f.Style.Label))
+	}
+}
+
+func cssColor(c color.RGBA) string {
+	if c.A == 0 {
+		return "none"
+	}
+	if c.A == 255 {
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("rgba(%d,%d,%d,%.3f)", c.R, c.G, c.B, float64(c.A)/255)
+}
+
+func escapeXML(s string) string {
+	replacer := strings.NewReplacer(`&`, "&amp;", `<`, "&lt;", `>`, "&gt;", `"`, "&quot;")
+	return replacer.Replace(s)
+}